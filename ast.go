@@ -0,0 +1,313 @@
+package mib
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/goller/mib/tokens"
+)
+
+// Module is a single MIB module; the text between
+// `NAME DEFINITIONS ::= BEGIN` and `END`.
+type Module struct {
+	Pos         tokens.Position
+	Name        string
+	Exports     []string
+	Imports     []Import
+	Assignments []Assignment
+}
+
+// Import is a group of symbols imported from a single module, e.g.
+// `OBJECT-TYPE, mib-2 FROM SNMPv2-SMI`. Symbols may name macros and
+// SMIv1 types such as Counter as well as values and types.
+type Import struct {
+	Pos       tokens.Position // position of the module name
+	Module    string
+	Symbols   []string
+	SymbolPos []tokens.Position // position of each of Symbols
+}
+
+// Dependencies returns the names of the modules imported by m in the order
+// in which they are first imported.
+func (m *Module) Dependencies() []string {
+	var deps []string
+	seen := make(map[string]bool)
+	for _, imp := range m.Imports {
+		if !seen[imp.Module] {
+			seen[imp.Module] = true
+			deps = append(deps, imp.Module)
+		}
+	}
+	return deps
+}
+
+// ImportOf returns the Import that brings sym into m, if any.
+func (m *Module) ImportOf(sym string) (Import, bool) {
+	for _, imp := range m.Imports {
+		for _, s := range imp.Symbols {
+			if s == sym {
+				return imp, true
+			}
+		}
+	}
+	return Import{}, false
+}
+
+// Assignment is any definition found in the body of a module.
+type Assignment interface {
+	// Ident returns the name being defined.
+	Ident() string
+	// Position returns the position of the name being defined.
+	Position() tokens.Position
+}
+
+// ValueAssignment defines a value such as an OBJECT IDENTIFIER or a macro
+// invocation that is not modelled, e.g. `ifMIB OBJECT IDENTIFIER ::= { mib-2 31 }`.
+type ValueAssignment struct {
+	Pos  tokens.Position
+	Name string
+	// Macro is the type of the first token after the name, e.g. Object
+	// or ObjGroup.
+	Macro tokens.TokenType
+	// Clauses are the tokens between the name and `::=`.
+	Clauses []tokens.Token
+	// OID is set when the value is an OBJECT IDENTIFIER value.
+	OID OID
+	// Value holds the tokens to the right of `::=`.
+	Value []tokens.Token
+}
+
+// Ident returns the name being defined.
+func (a *ValueAssignment) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *ValueAssignment) Position() tokens.Position { return a.Pos }
+
+// TypeAssignment defines a constructed type that is not modelled, e.g.
+// `ObjectSyntax ::= CHOICE { ... }`.
+type TypeAssignment struct {
+	Pos  tokens.Position
+	Name string
+	// Type holds the tokens to the right of `::=`.
+	Type []tokens.Token
+}
+
+// Ident returns the name being defined.
+func (a *TypeAssignment) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *TypeAssignment) Position() tokens.Position { return a.Pos }
+
+// ObjectType is an OBJECT-TYPE macro invocation, e.g.
+// `ifIndex OBJECT-TYPE SYNTAX InterfaceIndex ... ::= { ifEntry 1 }`.
+// Quoted strings hold their text without quotes.
+type ObjectType struct {
+	Pos         tokens.Position
+	Name        string
+	Syntax      *Syntax
+	Units       string
+	Access      string // value of MAX-ACCESS, or ACCESS in SMIv1
+	Status      string
+	Description string
+	Reference   string
+	Index       []IndexItem
+	Augments    string
+	// DefVal holds the tokens between the brackets of DEFVAL.
+	DefVal []tokens.Token
+	OID    OID
+	// Extra holds the tokens of clauses that are not modelled, such as
+	// the PIB-INDEX of an SPPI module.
+	Extra []tokens.Token
+}
+
+// Ident returns the name being defined.
+func (a *ObjectType) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *ObjectType) Position() tokens.Position { return a.Pos }
+
+// ModuleIdentity is the MODULE-IDENTITY macro invocation describing a
+// module and its history.
+type ModuleIdentity struct {
+	Pos          tokens.Position
+	Name         string
+	LastUpdated  time.Time
+	Organization string
+	ContactInfo  string
+	Description  string
+	// Revisions are in the order written, which is newest first.
+	Revisions []Revision
+	OID       OID
+	// Extra holds the tokens of clauses that are not modelled, such as
+	// the SUBJECT-CATEGORIES of an SPPI module.
+	Extra []tokens.Token
+}
+
+// Ident returns the name being defined.
+func (a *ModuleIdentity) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *ModuleIdentity) Position() tokens.Position { return a.Pos }
+
+// Revision is a REVISION clause of a MODULE-IDENTITY.
+type Revision struct {
+	Pos         tokens.Position // position of the date
+	Date        time.Time
+	Description string
+}
+
+// Notification is a NOTIFICATION-TYPE or an SMIv1 TRAP-TYPE macro
+// invocation.
+type Notification struct {
+	Pos  tokens.Position
+	Name string
+	// Macro is NotifType or TrapType.
+	Macro tokens.TokenType
+	// Objects are the names in OBJECTS, or VARIABLES of a TRAP-TYPE.
+	Objects     []string
+	Status      string
+	Description string
+	Reference   string
+	// Enterprise and SpecificTrap are the ENTERPRISE and value of a
+	// TRAP-TYPE.
+	Enterprise   OID
+	SpecificTrap uint32
+	// OID is the value of a NOTIFICATION-TYPE. For a TRAP-TYPE it is
+	// Enterprise followed by 0 and SpecificTrap, as in RFC 3584.
+	OID OID
+	// Extra holds the tokens of clauses that are not modelled.
+	Extra []tokens.Token
+}
+
+// Ident returns the name being defined.
+func (a *Notification) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *Notification) Position() tokens.Position { return a.Pos }
+
+// IndexItem is an entry of the INDEX clause of a conceptual row. In SMIv1
+// it may be a type such as `OCTET STRING` rather than an object.
+type IndexItem struct {
+	Pos     tokens.Position
+	Name    string
+	Implied bool
+}
+
+// SequenceType defines the conceptual row of a table, e.g.
+// `IfEntry ::= SEQUENCE { ifIndex InterfaceIndex, ifDescr DisplayString }`.
+type SequenceType struct {
+	Pos     tokens.Position
+	Name    string
+	Columns []Column
+	// Table and Entry are the objects whose SYNTAX is SEQUENCE OF the
+	// type and the type; nil if the module does not define them.
+	Table *ObjectType
+	Entry *ObjectType
+}
+
+// Ident returns the name being defined.
+func (a *SequenceType) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *SequenceType) Position() tokens.Position { return a.Pos }
+
+// Column is an element of a SEQUENCE.
+type Column struct {
+	Pos    tokens.Position
+	Name   string
+	Syntax *Syntax
+}
+
+// TextualConvention is a TEXTUAL-CONVENTION, e.g.
+// `DisplayString ::= TEXTUAL-CONVENTION DISPLAY-HINT "255a" ... SYNTAX OCTET STRING (SIZE (0..255))`,
+// or a plain type assignment such as `MacAddress ::= OCTET STRING (SIZE (6))`,
+// which only has a Syntax.
+type TextualConvention struct {
+	Pos         tokens.Position
+	Name        string
+	Plain       bool // a plain type assignment
+	DisplayHint string
+	Status      string
+	Description string
+	Reference   string
+	Syntax      *Syntax
+	// Extra holds the tokens of clauses that are not modelled.
+	Extra []tokens.Token
+}
+
+// Ident returns the name being defined.
+func (a *TextualConvention) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *TextualConvention) Position() tokens.Position { return a.Pos }
+
+// Syntax is the type of an object or textual convention, such as
+// `INTEGER { up(1), down(2) }`, `OCTET STRING (SIZE (0..255 | 1024))` or
+// `[APPLICATION 1] IMPLICIT INTEGER (0..4294967295)`.
+type Syntax struct {
+	Pos tokens.Position
+	// Base is the name of the type as written, e.g. INTEGER, OCTET STRING,
+	// BITS, Integer32 or DisplayString, or SEQUENCE OF.
+	Base string
+	// Tag is the tag of an SMI application type, e.g. APPLICATION 1.
+	Tag      string
+	Implicit bool
+	// Of is the row type of a SEQUENCE OF.
+	Of *Syntax
+	// NamedNumbers are the enumerations of an INTEGER or the bits of BITS.
+	NamedNumbers []NamedNumber
+	// Size reports if Ranges constrain the size of the value rather than
+	// the value.
+	Size bool
+	// Ranges are the alternatives of the constraint; none means no
+	// constraint.
+	Ranges []Range
+}
+
+// NamedNumber is a name and value such as `up(1)`.
+type NamedNumber struct {
+	Name  string
+	Value *big.Int
+}
+
+// Range is a constraint `Min..Max`; a single value has Min equal to Max.
+type Range struct {
+	Min, Max *big.Int
+}
+
+// InRange reports if n satisfies the constraint of s: that it is within
+// one of the Ranges, or that there are none.
+func (s *Syntax) InRange(n *big.Int) bool {
+	if len(s.Ranges) == 0 {
+		return true
+	}
+	for _, r := range s.Ranges {
+		if n.Cmp(r.Min) >= 0 && n.Cmp(r.Max) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MacroDefinition is a macro such as `OBJECT-TYPE MACRO ::= BEGIN ... END`.
+// The body of the macro is not interpreted.
+type MacroDefinition struct {
+	Pos  tokens.Position
+	Name string
+}
+
+// Ident returns the name being defined.
+func (a *MacroDefinition) Ident() string { return a.Name }
+
+// Position returns the position of the name being defined.
+func (a *MacroDefinition) Position() tokens.Position { return a.Pos }
+
+// OID is an OBJECT IDENTIFIER value such as `{ iso org(3) dod(6) 1 }`.
+type OID []SubID
+
+// SubID is a single component of an OID. It has a name, a number or both.
+type SubID struct {
+	Name      string
+	Number    uint32
+	HasNumber bool
+}
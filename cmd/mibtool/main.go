@@ -0,0 +1,139 @@
+// Command mibtool inspects MIB files.
+//
+// Usage:
+//
+//	mibtool tokens [-format text|json|compact] <file>
+//
+// The tokens command prints every token the lexer finds in file with its
+// type, position and value.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/goller/mib/tokens"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const usage = "usage: mibtool tokens [-format text|json|compact] <file>"
+
+// run executes the command in args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "tokens" {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("tokens", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "text", "output `format`: text, json or compact")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	var write func(io.Writer, []tokens.Token) error
+	switch *format {
+	case "text":
+		write = writeText
+	case "json":
+		write = writeJSON
+	case "compact":
+		write = writeCompact
+	default:
+		fmt.Fprintf(stderr, "mibtool: unknown format %q\n", *format)
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "mibtool: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+	toks, err := lex(f)
+	if werr := write(stdout, toks); werr != nil {
+		fmt.Fprintf(stderr, "mibtool: %v\n", werr)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "mibtool: %s:%v\n", fs.Arg(0), err)
+		return 1
+	}
+	return 0
+}
+
+// lex returns the tokens of r through EOF, or through the Error token and
+// the error that stopped the scan.
+func lex(r io.Reader) ([]tokens.Token, error) {
+	l := tokens.NewReaderLexer(r)
+	var toks []tokens.Token
+	for {
+		tk := l.NextToken()
+		toks = append(toks, tk)
+		switch tk.Typ {
+		case tokens.EOF:
+			return toks, nil
+		case tokens.Error:
+			return toks, l.Err()
+		}
+	}
+}
+
+// writeText writes a table of the tokens.
+func writeText(w io.Writer, toks []tokens.Token) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	fmt.Fprintln(tw, "START\tEND\tTYPE\tVALUE")
+	for _, tk := range toks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%q\n", tk.Start, tk.End, tk.Typ, tk.Val)
+	}
+	return tw.Flush()
+}
+
+// writeCompact writes each token as `line:col Type value`.
+func writeCompact(w io.Writer, toks []tokens.Token) error {
+	for _, tk := range toks {
+		if _, err := fmt.Fprintf(w, "%s %s %q\n", tk.Start, tk.Typ, tk.Val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonPosition struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type jsonToken struct {
+	Type  string       `json:"type"`
+	Value string       `json:"value"`
+	Start jsonPosition `json:"start"`
+	End   jsonPosition `json:"end"`
+}
+
+// writeJSON writes the tokens as a JSON array.
+func writeJSON(w io.Writer, toks []tokens.Token) error {
+	out := make([]jsonToken, len(toks))
+	for i, tk := range toks {
+		out[i] = jsonToken{
+			Type:  tk.Typ.String(),
+			Value: tk.Val,
+			Start: jsonPosition{int(tk.Start.Offset), tk.Start.Line, tk.Start.Column},
+			End:   jsonPosition{int(tk.End.Offset), tk.End.Line, tk.End.Column},
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(out)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_tokens(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mibtool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "A-MIB")
+	if err := ioutil.WriteFile(name, []byte("a OBJECT-TYPE\n::= { b 1 }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"tokens", "-format", "compact", name}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+	want := `1:1 ValueReference "a"
+1:3 ObjType "OBJECT-TYPE"
+2:1 Equals "::="
+2:5 LeftBracket "{"
+2:7 ValueReference "b"
+2:9 Number "1"
+2:11 RightBracket "}"
+2:12 EOF ""
+`
+	if got := stdout.String(); got != want {
+		t.Errorf("compact output =\n%s\nwant\n%s", got, want)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"tokens", name}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `1:3   1:14 ObjType        "OBJECT-TYPE"`) {
+		t.Errorf("text output =\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"tokens", "-format", "json", name}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+	var toks []jsonToken
+	if err := json.Unmarshal(stdout.Bytes(), &toks); err != nil {
+		t.Fatal(err)
+	}
+	if len(toks) != 8 || toks[1].Type != "ObjType" || toks[1].End.Offset != 13 {
+		t.Errorf("json output = %+v", toks)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"parse"},
+		{"tokens"},
+		{"tokens", "-format", "xml", "A-MIB"},
+	}
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("run(%q) = %d, want 2", args, code)
+		}
+	}
+}
@@ -1,5 +1,722 @@
 // Package mib parses mib files.
 package mib
 
-// Parse reads
-func Parse() {}
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/goller/mib/tokens"
+)
+
+// Error describes a problem found while parsing a module.
+type Error struct {
+	Pos   tokens.Position
+	Token tokens.Token // token at which the problem was found
+	Msg   string
+	Err   error // underlying *tokens.SyntaxError, if any
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// Unwrap returns the underlying lexer error, if any.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads a single MIB module from src. The options configure the
+// lexer, e.g. tokens.SourceEncoding for a Latin-1 file.
+func Parse(src string, opts ...tokens.Option) (m *Module, err error) {
+	lex := tokens.NewLexer(src, opts...)
+	p := &parser{lex: lex, s: tokens.NewStream(lex)}
+	defer p.recover(&err)
+	p.next()
+	return p.parseModule(), nil
+}
+
+// parser is a recursive descent parser over the lexer's tokens.
+// Errors are raised with panic and caught in Parse.
+type parser struct {
+	lex *tokens.Lexer
+	s   *tokens.Stream
+	tok tokens.Token // current token
+}
+
+// next advances to the next token.
+func (p *parser) next() {
+	p.tok = p.s.Next()
+	if p.tok.Typ == tokens.Error {
+		panic(&Error{Pos: p.tok.Start, Token: p.tok, Msg: p.tok.Val, Err: p.lex.Err()})
+	}
+}
+
+// take returns the current token and advances.
+func (p *parser) take() tokens.Token {
+	tk := p.tok
+	p.next()
+	return tk
+}
+
+// expect consumes the current token if it is of type typ.
+func (p *parser) expect(typ tokens.TokenType, what string) tokens.Token {
+	if p.tok.Typ != typ {
+		p.unexpected(what)
+	}
+	return p.take()
+}
+
+// expectWord consumes the current token if it is a reference, label or
+// keyword.
+func (p *parser) expectWord(what string) tokens.Token {
+	if !isWord(p.tok) {
+		p.unexpected(what)
+	}
+	return p.take()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	p.errorAt(p.tok, format, args...)
+}
+
+// unexpected raises an `expected what, found token` error for the current
+// token. The error wraps an UnexpectedToken *tokens.SyntaxError so that it
+// can be told apart from other problems.
+func (p *parser) unexpected(what string) {
+	msg := fmt.Sprintf("expected %s, found %s", what, p.tok)
+	panic(&Error{
+		Pos:   p.tok.Start,
+		Token: p.tok,
+		Msg:   msg,
+		Err:   &tokens.SyntaxError{Kind: tokens.UnexpectedToken, Pos: p.tok.Start, Msg: msg},
+	})
+}
+
+// errorAt raises an error found at token tk.
+func (p *parser) errorAt(tk tokens.Token, format string, args ...interface{}) {
+	panic(&Error{Pos: tk.Start, Token: tk, Msg: fmt.Sprintf(format, args...)})
+}
+
+// recover turns panics raised by errorf into an error returned from Parse.
+func (p *parser) recover(errp *error) {
+	if e := recover(); e != nil {
+		perr, ok := e.(*Error)
+		if !ok {
+			panic(e)
+		}
+		*errp = perr
+	}
+}
+
+// isWord reports if the token is a reference, label or keyword; keywords are
+// allowed as names because SMIv1 modules define types such as Counter and
+// Gauge. The words that delimit a module are not, so that a truncated
+// definition does not consume the END of the module.
+func isWord(tk tokens.Token) bool {
+	switch tk.Typ {
+	case tokens.TypeReference, tokens.ValueReference, tokens.Label:
+		return true
+	case tokens.Quotestring, tokens.Binary, tokens.Hex,
+		tokens.End, tokens.Begin, tokens.Definitions:
+		return false
+	}
+	return tk.Typ > tokens.Keyword
+}
+
+func (p *parser) parseModule() *Module {
+	name := p.expectWord("module name")
+	m := &Module{
+		Pos:  name.Start,
+		Name: name.Val,
+	}
+	if p.tok.Typ == tokens.LeftBracket { // module object identifier
+		p.balanced()
+	}
+	if p.tok.Typ == tokens.PibDefinitions { // SPPI
+		p.next()
+	} else {
+		p.expect(tokens.Definitions, "DEFINITIONS")
+	}
+	p.expect(tokens.Equals, "::=")
+	p.expect(tokens.Begin, "BEGIN")
+	if p.tok.Typ == tokens.Exports {
+		m.Exports = p.parseExports()
+	}
+	if p.tok.Typ == tokens.Imports {
+		m.Imports = p.parseImports()
+	}
+	for p.tok.Typ != tokens.End {
+		if p.tok.Typ == tokens.EOF {
+			p.errorf("unexpected EOF; module %s is missing END", m.Name)
+		}
+		m.Assignments = append(m.Assignments, p.parseAssignment())
+	}
+	linkTables(m)
+	p.next()
+	if p.tok.Typ != tokens.EOF {
+		p.errorf("unexpected %s after END of module %s", p.tok, m.Name)
+	}
+	return m
+}
+
+// parseExports reads `EXPORTS a, b;`, where the list may be empty.
+func (p *parser) parseExports() []string {
+	p.next()
+	var syms []string
+	if p.tok.Typ == tokens.Semicolon {
+		p.next()
+		return syms
+	}
+	p.commaList(tokens.Semicolon, ";", func() {
+		syms = append(syms, p.expectWord("exported symbol").Val)
+	})
+	return syms
+}
+
+// commaList reads one or more items separated by commas and the closing
+// token that follows them; item reads a single item.
+func (p *parser) commaList(closing tokens.TokenType, what string, item func()) {
+	for {
+		item()
+		if p.tok.Typ == closing {
+			p.next()
+			return
+		}
+		p.expect(tokens.Comma, ", or "+what)
+	}
+}
+
+// parseImports reads `IMPORTS a, b FROM A-MIB c FROM B-MIB;`. The
+// symbols may be keywords such as OBJECT-TYPE or Counter.
+func (p *parser) parseImports() []Import {
+	p.next()
+	var (
+		imps []Import
+		syms []string
+		pos  []tokens.Position
+	)
+	for p.tok.Typ != tokens.Semicolon {
+		switch p.tok.Typ {
+		case tokens.Comma:
+			p.next()
+		case tokens.From:
+			p.next()
+			mod := p.expectWord("module name")
+			imps = append(imps, Import{
+				Pos:       mod.Start,
+				Module:    mod.Val,
+				Symbols:   syms,
+				SymbolPos: pos,
+			})
+			syms, pos = nil, nil
+			if p.tok.Typ == tokens.LeftBracket { // module object identifier
+				p.balanced()
+			}
+		default:
+			sym := p.expectWord("imported symbol")
+			syms = append(syms, sym.Val)
+			pos = append(pos, sym.Start)
+		}
+	}
+	if len(syms) > 0 {
+		p.errorf("imported symbols %v are missing FROM", syms)
+	}
+	p.next()
+	return imps
+}
+
+func (p *parser) parseAssignment() Assignment {
+	tk := p.expectWord("assignment")
+	name := tk.Val
+	switch p.tok.Typ {
+	case tokens.Macro:
+		p.next()
+		p.expect(tokens.Equals, "::=")
+		p.expect(tokens.Begin, "BEGIN")
+		for depth := 1; depth > 0; p.next() {
+			switch p.tok.Typ {
+			case tokens.Begin:
+				depth++
+			case tokens.End:
+				depth--
+			case tokens.EOF:
+				p.errorf("unexpected EOF in macro %s", name)
+			}
+		}
+		return &MacroDefinition{Pos: tk.Start, Name: name}
+	case tokens.Equals:
+		p.next()
+		switch {
+		case p.tok.Typ == tokens.Convention:
+			return p.parseTextualConvention(tk)
+		case p.tok.Typ == tokens.Sequence && p.s.Peek(0).Typ == tokens.LeftBracket:
+			return p.parseSequence(tk)
+		case p.tok.Typ == tokens.Choice:
+			return &TypeAssignment{Pos: tk.Start, Name: name, Type: p.parseType()}
+		}
+		return &TextualConvention{Pos: tk.Start, Name: name, Plain: true, Syntax: p.parseSyntax()}
+	case tokens.ObjType:
+		return p.parseObjectType(tk)
+	case tokens.ModuleIdentify:
+		return p.parseModuleIdentity(tk)
+	case tokens.NotifType, tokens.TrapType:
+		return p.parseNotification(tk)
+	}
+
+	a := &ValueAssignment{
+		Pos:   tk.Start,
+		Name:  name,
+		Macro: p.tok.Typ,
+	}
+	for p.tok.Typ != tokens.Equals {
+		if p.tok.Typ == tokens.EOF || p.tok.Typ == tokens.End {
+			p.unexpected("::= in definition of " + name)
+		}
+		a.Clauses = append(a.Clauses, p.take())
+	}
+	p.next()
+	if p.tok.Typ == tokens.LeftBracket {
+		a.Value = p.balanced()
+		a.OID = p.oid(a.Value)
+	} else {
+		switch p.tok.Typ {
+		case tokens.EOF, tokens.End, tokens.Begin, tokens.Definitions:
+			p.unexpected("value of " + name)
+		}
+		a.Value = []tokens.Token{p.take()}
+	}
+	return a
+}
+
+// parseObjectType reads the clauses and value of an OBJECT-TYPE; the
+// current token is OBJECT-TYPE.
+func (p *parser) parseObjectType(name tokens.Token) *ObjectType {
+	p.next()
+	o := &ObjectType{Pos: name.Start, Name: name.Val}
+	for p.tok.Typ != tokens.Equals {
+		switch p.tok.Typ {
+		case tokens.Syntax:
+			p.next()
+			o.Syntax = p.parseSyntax()
+		case tokens.Units:
+			p.next()
+			o.Units = p.expect(tokens.Quotestring, "UNITS string").Text()
+		case tokens.Access:
+			p.next()
+			o.Access = p.expectWord("access").Val
+		case tokens.Status:
+			p.next()
+			o.Status = p.expectWord("status").Val
+		case tokens.Description:
+			p.next()
+			o.Description = p.expect(tokens.Quotestring, "DESCRIPTION string").Text()
+		case tokens.Reference:
+			p.next()
+			o.Reference = p.expect(tokens.Quotestring, "REFERENCE string").Text()
+		case tokens.Index:
+			p.next()
+			o.Index = p.parseIndex()
+		case tokens.Augments:
+			p.next()
+			p.expect(tokens.LeftBracket, "{")
+			o.Augments = p.expectWord("augmented row").Val
+			p.expect(tokens.RightBracket, "}")
+		case tokens.Defval:
+			p.next()
+			if p.tok.Typ != tokens.LeftBracket {
+				p.unexpected("{ after DEFVAL")
+			}
+			group := p.balanced()
+			o.DefVal = group[1 : len(group)-1]
+		default:
+			o.Extra = append(o.Extra, p.parseClause(name.Val)...)
+		}
+	}
+	o.OID = p.parseOIDValue(name.Val)
+	return o
+}
+
+// parseModuleIdentity reads the clauses and value of a MODULE-IDENTITY;
+// the current token is MODULE-IDENTITY.
+func (p *parser) parseModuleIdentity(name tokens.Token) *ModuleIdentity {
+	p.next()
+	mi := &ModuleIdentity{Pos: name.Start, Name: name.Val}
+	for p.tok.Typ != tokens.Equals {
+		switch p.tok.Typ {
+		case tokens.LastUpdated:
+			p.next()
+			mi.LastUpdated = p.extUTCTime()
+		case tokens.Organization:
+			p.next()
+			mi.Organization = p.expect(tokens.Quotestring, "ORGANIZATION string").Text()
+		case tokens.ContactInfo:
+			p.next()
+			mi.ContactInfo = p.expect(tokens.Quotestring, "CONTACT-INFO string").Text()
+		case tokens.Description:
+			p.next()
+			mi.Description = p.expect(tokens.Quotestring, "DESCRIPTION string").Text()
+		case tokens.Revision:
+			p.next()
+			rev := Revision{Pos: p.tok.Start}
+			rev.Date = p.extUTCTime()
+			p.expect(tokens.Description, "DESCRIPTION of REVISION")
+			rev.Description = p.expect(tokens.Quotestring, "DESCRIPTION string").Text()
+			mi.Revisions = append(mi.Revisions, rev)
+		default:
+			mi.Extra = append(mi.Extra, p.parseClause(name.Val)...)
+		}
+	}
+	mi.OID = p.parseOIDValue(name.Val)
+	return mi
+}
+
+// parseTextualConvention reads the clauses of a TEXTUAL-CONVENTION, which
+// end with SYNTAX; the current token is TEXTUAL-CONVENTION.
+func (p *parser) parseTextualConvention(name tokens.Token) *TextualConvention {
+	p.next()
+	tc := &TextualConvention{Pos: name.Start, Name: name.Val}
+	for {
+		switch p.tok.Typ {
+		case tokens.Syntax:
+			p.next()
+			tc.Syntax = p.parseSyntax()
+			return tc
+		case tokens.DisplayHint:
+			p.next()
+			tc.DisplayHint = p.expect(tokens.Quotestring, "DISPLAY-HINT string").Text()
+		case tokens.Status:
+			p.next()
+			tc.Status = p.expectWord("status").Val
+		case tokens.Description:
+			p.next()
+			tc.Description = p.expect(tokens.Quotestring, "DESCRIPTION string").Text()
+		case tokens.Reference:
+			p.next()
+			tc.Reference = p.expect(tokens.Quotestring, "REFERENCE string").Text()
+		case tokens.EOF, tokens.End, tokens.Equals:
+			p.unexpected("SYNTAX in TEXTUAL-CONVENTION")
+		default:
+			tc.Extra = append(tc.Extra, p.parseClause(name.Val)...)
+		}
+	}
+}
+
+// parseNotification reads the clauses and value of a NOTIFICATION-TYPE or
+// TRAP-TYPE; the current token is the macro name.
+func (p *parser) parseNotification(name tokens.Token) *Notification {
+	n := &Notification{Pos: name.Start, Name: name.Val, Macro: p.take().Typ}
+	for p.tok.Typ != tokens.Equals {
+		switch p.tok.Typ {
+		case tokens.Objects, tokens.Variables:
+			p.next()
+			n.Objects = p.parseNames()
+		case tokens.Status:
+			p.next()
+			n.Status = p.expectWord("status").Val
+		case tokens.Description:
+			p.next()
+			n.Description = p.expect(tokens.Quotestring, "DESCRIPTION string").Text()
+		case tokens.Reference:
+			p.next()
+			n.Reference = p.expect(tokens.Quotestring, "REFERENCE string").Text()
+		case tokens.Enterprise:
+			p.next()
+			if p.tok.Typ == tokens.LeftBracket {
+				n.Enterprise = p.oid(p.balanced())
+			} else {
+				n.Enterprise = OID{{Name: p.expectWord("enterprise").Val}}
+			}
+		default:
+			n.Extra = append(n.Extra, p.parseClause(name.Val)...)
+		}
+	}
+	if n.Macro == tokens.NotifType {
+		n.OID = p.parseOIDValue(name.Val)
+		return n
+	}
+
+	p.next()
+	tk := p.expect(tokens.Number, "trap number")
+	v, err := tk.Uint64()
+	if err != nil || v > math.MaxUint32 {
+		p.errorAt(tk, "invalid trap number %s", tk)
+	}
+	n.SpecificTrap = uint32(v)
+	if n.Enterprise != nil {
+		n.OID = append(append(OID{}, n.Enterprise...),
+			SubID{Number: 0, HasNumber: true},
+			SubID{Number: n.SpecificTrap, HasNumber: true})
+	}
+	return n
+}
+
+// parseNames reads `{ a, b }`.
+func (p *parser) parseNames() []string {
+	p.expect(tokens.LeftBracket, "{")
+	var names []string
+	p.commaList(tokens.RightBracket, "}", func() {
+		names = append(names, p.expectWord("object name").Val)
+	})
+	return names
+}
+
+// extUTCTime reads a quoted ExtUTCTime, "YYMMDDHHMMZ" or "YYYYMMDDHHMMZ";
+// RFC 2578 limits two digit years to the 1900s.
+func (p *parser) extUTCTime() time.Time {
+	tk := p.expect(tokens.Quotestring, "ExtUTCTime string")
+	s := tk.Text()
+	if len(s) == len("YYMMDDHHMMZ") {
+		s = "19" + s
+	}
+	t, err := time.Parse("200601021504Z", s)
+	if err != nil {
+		p.errorAt(tk, "invalid ExtUTCTime %s", tk)
+	}
+	return t
+}
+
+// parseClause reads a clause that is not modelled: a keyword followed by
+// a bracketed group or a single token.
+func (p *parser) parseClause(name string) []tokens.Token {
+	if !isWord(p.tok) {
+		p.unexpected("::= in definition of " + name)
+	}
+	clause := []tokens.Token{p.take()}
+	switch {
+	case p.tok.Typ == tokens.LeftBracket:
+		return append(clause, p.balanced()...)
+	case p.tok.Typ == tokens.Equals || p.tok.Typ == tokens.EOF || p.tok.Typ == tokens.End:
+		return clause
+	}
+	return append(clause, p.take())
+}
+
+// parseIndex reads `{ IMPLIED a, b }`.
+func (p *parser) parseIndex() []IndexItem {
+	p.expect(tokens.LeftBracket, "{")
+	var items []IndexItem
+	p.commaList(tokens.RightBracket, "}", func() {
+		var item IndexItem
+		if p.tok.Typ == tokens.Implied {
+			item.Implied = true
+			p.next()
+		}
+		tk := p.expectWord("index")
+		item.Pos, item.Name = tk.Start, tk.Val
+		if tk.Typ == tokens.Continue || tk.Typ == tokens.Object { // OCTET STRING
+			item.Name += " " + p.expectWord("type").Val
+		}
+		items = append(items, item)
+	})
+	return items
+}
+
+// parseOIDValue reads `::= { parent 1 }` at the end of a macro invocation.
+func (p *parser) parseOIDValue(name string) OID {
+	p.expect(tokens.Equals, "::=")
+	if p.tok.Typ != tokens.LeftBracket {
+		p.unexpected("object identifier value of " + name)
+	}
+	return p.oid(p.balanced())
+}
+
+// parseSyntax reads a type such as `INTEGER { up(1), down(2) }`,
+// `OCTET STRING (SIZE (0..255 | 1024))`, `[APPLICATION 1] IMPLICIT INTEGER`
+// or `SEQUENCE OF IfEntry`.
+func (p *parser) parseSyntax() *Syntax {
+	s := &Syntax{Pos: p.tok.Start}
+	if p.tok.Typ == tokens.LeftSquareBracket {
+		group := p.balanced()
+		var tag []string
+		for _, tk := range group[1 : len(group)-1] {
+			tag = append(tag, tk.Val)
+		}
+		s.Tag = strings.Join(tag, " ")
+	}
+	if p.tok.Typ == tokens.Implicit {
+		s.Implicit = true
+		p.next()
+	}
+
+	switch p.tok.Typ {
+	case tokens.Continue, tokens.Object: // OCTET STRING, BIT STRING, OBJECT IDENTIFIER
+		s.Base = p.take().Val + " " + p.expectWord("type").Val
+	case tokens.Sequence:
+		p.next()
+		p.expect(tokens.Of, "OF")
+		s.Base = "SEQUENCE OF"
+		s.Of = p.parseSyntax()
+		return s
+	default:
+		s.Base = p.expectWord("type").Val
+	}
+
+	if p.tok.Typ == tokens.LeftBracket {
+		s.NamedNumbers = p.parseNamedNumbers()
+	}
+	if p.tok.Typ == tokens.LeftParen {
+		p.next()
+		if p.tok.Typ == tokens.Size {
+			p.next()
+			s.Size = true
+			p.expect(tokens.LeftParen, "(")
+			s.Ranges = p.parseRanges()
+			p.expect(tokens.RightParen, ")")
+		} else {
+			s.Ranges = p.parseRanges()
+		}
+		p.expect(tokens.RightParen, ")")
+	}
+	return s
+}
+
+// parseNamedNumbers reads `{ up(1), down(2) }`.
+func (p *parser) parseNamedNumbers() []NamedNumber {
+	p.expect(tokens.LeftBracket, "{")
+	var nums []NamedNumber
+	p.commaList(tokens.RightBracket, "}", func() {
+		name := p.expectWord("named number")
+		p.expect(tokens.LeftParen, "(")
+		nums = append(nums, NamedNumber{Name: name.Val, Value: p.number()})
+		p.expect(tokens.RightParen, ")")
+	})
+	return nums
+}
+
+// parseRanges reads the alternatives of a constraint, `0 | 5..10`.
+func (p *parser) parseRanges() []Range {
+	var ranges []Range
+	for {
+		r := Range{Min: p.number()}
+		r.Max = r.Min
+		if p.tok.Typ == tokens.Range {
+			p.next()
+			r.Max = p.number()
+		}
+		ranges = append(ranges, r)
+		if p.tok.Typ != tokens.Bar {
+			return ranges
+		}
+		p.next()
+	}
+}
+
+// number reads a number, which may be negative or a 'H or 'B literal.
+func (p *parser) number() *big.Int {
+	switch p.tok.Typ {
+	case tokens.Number, tokens.NegativeNumber, tokens.Hex, tokens.Binary:
+	default:
+		p.unexpected("number")
+	}
+	n, err := p.tok.BigInt()
+	if err != nil {
+		p.errorf("invalid number %s", p.tok)
+	}
+	p.next()
+	return n
+}
+
+// parseSequence reads `SEQUENCE { a Type, b Type }`; the current token
+// is SEQUENCE.
+func (p *parser) parseSequence(name tokens.Token) *SequenceType {
+	p.next()
+	p.expect(tokens.LeftBracket, "{")
+	seq := &SequenceType{Pos: name.Start, Name: name.Val}
+	p.commaList(tokens.RightBracket, "}", func() {
+		col := p.expectWord("column name")
+		seq.Columns = append(seq.Columns, Column{
+			Pos:    col.Start,
+			Name:   col.Val,
+			Syntax: p.parseSyntax(),
+		})
+	})
+	return seq
+}
+
+// linkTables sets the Table and Entry of each SequenceType in m.
+func linkTables(m *Module) {
+	seqs := make(map[string]*SequenceType)
+	for _, a := range m.Assignments {
+		if seq, ok := a.(*SequenceType); ok {
+			seqs[seq.Name] = seq
+		}
+	}
+	for _, a := range m.Assignments {
+		o, ok := a.(*ObjectType)
+		if !ok || o.Syntax == nil {
+			continue
+		}
+		if o.Syntax.Of != nil {
+			if seq := seqs[o.Syntax.Of.Base]; seq != nil && seq.Table == nil {
+				seq.Table = o
+			}
+		} else if seq := seqs[o.Syntax.Base]; seq != nil && seq.Entry == nil {
+			seq.Entry = o
+		}
+	}
+}
+
+// parseType reads a constructed type such as `CHOICE { ... }`.
+func (p *parser) parseType() []tokens.Token {
+	typ := []tokens.Token{p.take()}
+	if p.tok.Typ != tokens.LeftBracket {
+		p.unexpected("{ after " + typ[0].Val)
+	}
+	return append(typ, p.balanced()...)
+}
+
+// balanced reads a bracketed group of tokens including the brackets; the
+// current token must be an opening bracket.
+func (p *parser) balanced() []tokens.Token {
+	var group []tokens.Token
+	depth := 0
+	for {
+		switch p.tok.Typ {
+		case tokens.LeftParen, tokens.LeftBracket, tokens.LeftSquareBracket:
+			depth++
+		case tokens.RightParen, tokens.RightBracket, tokens.RightSquareBracket:
+			depth--
+		case tokens.EOF:
+			p.errorf("unexpected EOF; unbalanced brackets")
+		}
+		group = append(group, p.take())
+		if depth == 0 {
+			return group
+		}
+	}
+}
+
+// oid interprets the tokens of `{ iso org(3) 6 }` as an OID.
+func (p *parser) oid(group []tokens.Token) OID {
+	var oid OID
+	group = group[1 : len(group)-1]
+	for i := 0; i < len(group); i++ {
+		tk := group[i]
+		if tk.Typ == tokens.Number {
+			oid = append(oid, SubID{Number: p.subID(tk), HasNumber: true})
+			continue
+		}
+		if !isWord(tk) {
+			p.errorAt(tk, "unexpected %s in object identifier", tk)
+		}
+		sub := SubID{Name: tk.Val}
+		if i+3 < len(group) && group[i+1].Typ == tokens.LeftParen && group[i+3].Typ == tokens.RightParen {
+			sub.Number, sub.HasNumber = p.subID(group[i+2]), true
+			i += 3
+		}
+		oid = append(oid, sub)
+	}
+	return oid
+}
+
+// subID returns the value of a sub-identifier, which is 32 bits.
+func (p *parser) subID(tk tokens.Token) uint32 {
+	n, err := tk.Uint64()
+	if err != nil || n > math.MaxUint32 {
+		p.errorAt(tk, "invalid sub-identifier %s", tk)
+	}
+	return uint32(n)
+}
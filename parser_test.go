@@ -0,0 +1,766 @@
+package mib
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goller/mib/tokens"
+)
+
+const smallMIB = `
+SMALL-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+	MODULE-IDENTITY, OBJECT-TYPE, Integer32, mib-2
+		FROM SNMPv2-SMI
+	DisplayString
+		FROM SNMPv2-TC;
+
+smallMIB OBJECT IDENTIFIER ::= { mib-2 999 }
+
+SmallIndex ::= TEXTUAL-CONVENTION
+	DISPLAY-HINT "d"
+	STATUS       current
+	DESCRIPTION  "An index."
+	SYNTAX       Integer32 (1..2147483647)
+
+smallTable OBJECT-TYPE
+	SYNTAX      SEQUENCE OF SmallEntry
+	MAX-ACCESS  not-accessible
+	STATUS      current
+	DESCRIPTION "A table."
+	::= { smallMIB 1 }
+
+smallEntry OBJECT-TYPE
+	SYNTAX      SmallEntry
+	MAX-ACCESS  not-accessible
+	STATUS      current
+	DESCRIPTION "A row."
+	INDEX       { smallIndex }
+	::= { smallTable 1 }
+
+SmallEntry ::= SEQUENCE {
+	smallIndex SmallIndex,
+	smallDescr DisplayString
+}
+
+smallIndex OBJECT-TYPE
+	SYNTAX      SmallIndex
+	MAX-ACCESS  read-only
+	STATUS      current
+	DESCRIPTION "The index."
+	::= { smallEntry 1 }
+
+smallDescr OBJECT-TYPE
+	SYNTAX      DisplayString (SIZE (0..255))
+	MAX-ACCESS  read-only
+	STATUS      current
+	DESCRIPTION "A description."
+	::= { smallEntry 2 }
+
+END
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse(smallMIB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Name, "SMALL-MIB"; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+	wantImports := []Import{
+		{
+			Pos:     tokens.Position{Offset: 97, Line: 6, Column: 8},
+			Module:  "SNMPv2-SMI",
+			Symbols: []string{"MODULE-IDENTITY", "OBJECT-TYPE", "Integer32", "mib-2"},
+			SymbolPos: []tokens.Position{
+				{Offset: 43, Line: 5, Column: 2},
+				{Offset: 60, Line: 5, Column: 19},
+				{Offset: 73, Line: 5, Column: 32},
+				{Offset: 84, Line: 5, Column: 43},
+			},
+		},
+		{
+			Pos:       tokens.Position{Offset: 130, Line: 8, Column: 8},
+			Module:    "SNMPv2-TC",
+			Symbols:   []string{"DisplayString"},
+			SymbolPos: []tokens.Position{{Offset: 109, Line: 7, Column: 2}},
+		},
+	}
+	if !reflect.DeepEqual(m.Imports, wantImports) {
+		t.Errorf("Imports = %v, want %v", m.Imports, wantImports)
+	}
+
+	var names []string
+	for _, a := range m.Assignments {
+		names = append(names, a.Ident())
+	}
+	wantNames := []string{"smallMIB", "SmallIndex", "smallTable", "smallEntry", "SmallEntry", "smallIndex", "smallDescr"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("assignments = %v, want %v", names, wantNames)
+	}
+
+	root, ok := m.Assignments[0].(*ValueAssignment)
+	if !ok {
+		t.Fatalf("smallMIB is %T, want *ValueAssignment", m.Assignments[0])
+	}
+	wantOID := OID{{Name: "mib-2"}, {Number: 999, HasNumber: true}}
+	if !reflect.DeepEqual(root.OID, wantOID) {
+		t.Errorf("OID = %v, want %v", root.OID, wantOID)
+	}
+	if _, ok := m.Assignments[1].(*TextualConvention); !ok {
+		t.Errorf("SmallIndex is %T, want *TextualConvention", m.Assignments[1])
+	}
+	if _, ok := m.Assignments[2].(*ObjectType); !ok {
+		t.Errorf("smallTable is %T, want *ObjectType", m.Assignments[2])
+	}
+}
+
+func TestParse_imports(t *testing.T) {
+	m, err := Parse(`RFC1213-MIB DEFINITIONS ::= BEGIN
+		IMPORTS
+			mgmt, NetworkAddress, IpAddress, Counter, Gauge, TimeTicks
+				FROM RFC1155-SMI
+			OBJECT-TYPE
+				FROM RFC-1212
+			TRAP-TYPE
+				FROM RFC-1215
+			MODULE-IDENTITY, NOTIFICATION-TYPE, OBJECT-IDENTITY, Counter64
+				FROM SNMPv2-SMI
+			TEXTUAL-CONVENTION FROM SNMPv2-TC
+			MODULE-COMPLIANCE, OBJECT-GROUP FROM SNMPv2-CONF
+			Gauge32 FROM SNMPv2-SMI;
+	END`, tokens.UseDialect(tokens.SMIv1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]string
+	for _, imp := range m.Imports {
+		got = append(got, append([]string{imp.Module}, imp.Symbols...))
+		if len(imp.SymbolPos) != len(imp.Symbols) {
+			t.Errorf("%s: %d positions for %d symbols", imp.Module, len(imp.SymbolPos), len(imp.Symbols))
+		}
+	}
+	want := [][]string{
+		{"RFC1155-SMI", "mgmt", "NetworkAddress", "IpAddress", "Counter", "Gauge", "TimeTicks"},
+		{"RFC-1212", "OBJECT-TYPE"},
+		{"RFC-1215", "TRAP-TYPE"},
+		{"SNMPv2-SMI", "MODULE-IDENTITY", "NOTIFICATION-TYPE", "OBJECT-IDENTITY", "Counter64"},
+		{"SNMPv2-TC", "TEXTUAL-CONVENTION"},
+		{"SNMPv2-CONF", "MODULE-COMPLIANCE", "OBJECT-GROUP"},
+		{"SNMPv2-SMI", "Gauge32"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Imports = %v, want %v", got, want)
+	}
+
+	wantDeps := []string{"RFC1155-SMI", "RFC-1212", "RFC-1215", "SNMPv2-SMI", "SNMPv2-TC", "SNMPv2-CONF"}
+	if deps := m.Dependencies(); !reflect.DeepEqual(deps, wantDeps) {
+		t.Errorf("Dependencies() = %v, want %v", deps, wantDeps)
+	}
+	if imp, ok := m.ImportOf("Gauge32"); !ok || imp.Module != "SNMPv2-SMI" || imp.Pos.Line != 13 {
+		t.Errorf("ImportOf(Gauge32) = %v, %v, want SNMPv2-SMI at line 13", imp, ok)
+	}
+	if imp, ok := m.ImportOf("DisplayString"); ok {
+		t.Errorf("ImportOf(DisplayString) = %v, want none", imp)
+	}
+}
+
+func TestParse_objectType(t *testing.T) {
+	m, err := Parse(`IF-MIB DEFINITIONS ::= BEGIN
+		ifStackEntry OBJECT-TYPE
+			SYNTAX      IfStackEntry
+			MAX-ACCESS  not-accessible
+			STATUS      current
+			DESCRIPTION "An entry."
+			REFERENCE   "RFC 2863"
+			INDEX       { ifStackHigherLayer, IMPLIED ifStackLowerLayer }
+			::= { ifStackTable 1 }
+		ifRcvAddressType OBJECT-TYPE
+			SYNTAX      INTEGER { other(1), volatile(2), nonVolatile(3) }
+			UNITS       "types"
+			MAX-ACCESS  read-create
+			STATUS      current
+			DEFVAL      { volatile }
+			::= { ifRcvAddressEntry 3 }
+		ifXEntry OBJECT-TYPE
+			SYNTAX      IfXEntry
+			MAX-ACCESS  not-accessible
+			STATUS      current
+			AUGMENTS    { ifEntry }
+			::= { ifXTable 1 }
+		atEntry OBJECT-TYPE
+			SYNTAX  AtEntry
+			ACCESS  not-accessible
+			STATUS  deprecated
+			INDEX   { atIfIndex, OCTET STRING }
+			::= { atTable 1 }
+	END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var objs []*ObjectType
+	for _, a := range m.Assignments {
+		o, ok := a.(*ObjectType)
+		if !ok {
+			t.Fatalf("%s is %T, want *ObjectType", a.Ident(), a)
+		}
+		objs = append(objs, o)
+	}
+
+	stack := objs[0]
+	if stack.Access != "not-accessible" || stack.Status != "current" ||
+		stack.Description != "An entry." || stack.Reference != "RFC 2863" {
+		t.Errorf("ifStackEntry = %+v", stack)
+	}
+	wantIndex := []IndexItem{
+		{Pos: tokens.Position{Offset: 207, Line: 8, Column: 18}, Name: "ifStackHigherLayer"},
+		{Pos: tokens.Position{Offset: 235, Line: 8, Column: 46}, Name: "ifStackLowerLayer", Implied: true},
+	}
+	if !reflect.DeepEqual(stack.Index, wantIndex) {
+		t.Errorf("Index = %+v, want %+v", stack.Index, wantIndex)
+	}
+	wantOID := OID{{Name: "ifStackTable"}, {Number: 1, HasNumber: true}}
+	if !reflect.DeepEqual(stack.OID, wantOID) {
+		t.Errorf("OID = %v, want %v", stack.OID, wantOID)
+	}
+
+	addr := objs[1]
+	if addr.Syntax.Base != "INTEGER" || len(addr.Syntax.NamedNumbers) != 3 {
+		t.Errorf("Syntax = %+v, want INTEGER with 3 named numbers", addr.Syntax)
+	}
+	if addr.Units != "types" || addr.Access != "read-create" {
+		t.Errorf("ifRcvAddressType = %+v", addr)
+	}
+	if len(addr.DefVal) != 1 || addr.DefVal[0].Val != "volatile" {
+		t.Errorf("DefVal = %v, want [volatile]", addr.DefVal)
+	}
+
+	if got := objs[2].Augments; got != "ifEntry" {
+		t.Errorf("Augments = %q, want ifEntry", got)
+	}
+
+	at := objs[3]
+	if at.Access != "not-accessible" || at.Status != "deprecated" {
+		t.Errorf("atEntry = %+v", at)
+	}
+	if len(at.Index) != 2 || at.Index[1].Name != "OCTET STRING" {
+		t.Errorf("Index = %+v, want [atIfIndex OCTET STRING]", at.Index)
+	}
+}
+
+func TestParse_moduleIdentity(t *testing.T) {
+	m, err := Parse(`IF-MIB DEFINITIONS ::= BEGIN
+		ifMIB MODULE-IDENTITY
+			LAST-UPDATED "200006140000Z"
+			ORGANIZATION "IETF Interfaces MIB Working Group"
+			CONTACT-INFO "Keith McCloghrie"
+			DESCRIPTION  "The MIB module."
+			REVISION     "200006140000Z"
+			DESCRIPTION  "Clarifications."
+			REVISION     "9602282155Z"
+			DESCRIPTION  "Revisions made by the Interfaces MIB WG."
+			::= { mib-2 31 }
+	END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mi, ok := m.Assignments[0].(*ModuleIdentity)
+	if !ok {
+		t.Fatalf("ifMIB is %T, want *ModuleIdentity", m.Assignments[0])
+	}
+	if want := time.Date(2000, 6, 14, 0, 0, 0, 0, time.UTC); !mi.LastUpdated.Equal(want) {
+		t.Errorf("LastUpdated = %v, want %v", mi.LastUpdated, want)
+	}
+	if mi.Organization != "IETF Interfaces MIB Working Group" || mi.ContactInfo != "Keith McCloghrie" || mi.Description != "The MIB module." {
+		t.Errorf("ifMIB = %+v", mi)
+	}
+	wantRevs := []Revision{
+		{
+			Pos:         tokens.Position{Offset: 222, Line: 7, Column: 17},
+			Date:        time.Date(2000, 6, 14, 0, 0, 0, 0, time.UTC),
+			Description: "Clarifications.",
+		},
+		{
+			Pos:         tokens.Position{Offset: 288, Line: 9, Column: 17},
+			Date:        time.Date(1996, 2, 28, 21, 55, 0, 0, time.UTC),
+			Description: "Revisions made by the Interfaces MIB WG.",
+		},
+	}
+	if !reflect.DeepEqual(mi.Revisions, wantRevs) {
+		t.Errorf("Revisions = %+v, want %+v", mi.Revisions, wantRevs)
+	}
+	wantOID := OID{{Name: "mib-2"}, {Number: 31, HasNumber: true}}
+	if !reflect.DeepEqual(mi.OID, wantOID) {
+		t.Errorf("OID = %v, want %v", mi.OID, wantOID)
+	}
+}
+
+func TestParse_notification(t *testing.T) {
+	m, err := Parse(`NOTES-MIB DEFINITIONS ::= BEGIN
+		linkDown NOTIFICATION-TYPE
+			OBJECTS     { ifIndex, ifAdminStatus, ifOperStatus }
+			STATUS      current
+			DESCRIPTION "A link went down."
+			::= { snmpTraps 3 }
+		myLinkDown TRAP-TYPE
+			ENTERPRISE  myEnterprise
+			VARIABLES   { ifIndex }
+			DESCRIPTION "A link went down."
+			REFERENCE   "RFC 1215"
+			::= 2
+		coldStart TRAP-TYPE
+			ENTERPRISE  { iso 3 6 1 }
+			::= 0
+	END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Notification{
+		{
+			Pos:         tokens.Position{Offset: 34, Line: 2, Column: 3},
+			Name:        "linkDown",
+			Macro:       tokens.NotifType,
+			Objects:     []string{"ifIndex", "ifAdminStatus", "ifOperStatus"},
+			Status:      "current",
+			Description: "A link went down.",
+			OID:         OID{{Name: "snmpTraps"}, {Number: 3, HasNumber: true}},
+		},
+		{
+			Pos:          tokens.Position{Offset: 200, Line: 7, Column: 3},
+			Name:         "myLinkDown",
+			Macro:        tokens.TrapType,
+			Objects:      []string{"ifIndex"},
+			Description:  "A link went down.",
+			Reference:    "RFC 1215",
+			Enterprise:   OID{{Name: "myEnterprise"}},
+			SpecificTrap: 2,
+			OID:          OID{{Name: "myEnterprise"}, {Number: 0, HasNumber: true}, {Number: 2, HasNumber: true}},
+		},
+		{
+			Pos:   tokens.Position{Offset: 348, Line: 13, Column: 3},
+			Name:  "coldStart",
+			Macro: tokens.TrapType,
+			Enterprise: OID{
+				{Name: "iso"},
+				{Number: 3, HasNumber: true},
+				{Number: 6, HasNumber: true},
+				{Number: 1, HasNumber: true},
+			},
+			OID: OID{
+				{Name: "iso"},
+				{Number: 3, HasNumber: true},
+				{Number: 6, HasNumber: true},
+				{Number: 1, HasNumber: true},
+				{Number: 0, HasNumber: true},
+				{Number: 0, HasNumber: true},
+			},
+		},
+	}
+	for i, a := range m.Assignments {
+		if !reflect.DeepEqual(a, want[i]) {
+			t.Errorf("Assignments[%d] = %+v, want %+v", i, a, want[i])
+		}
+	}
+}
+
+func TestParse_textualConvention(t *testing.T) {
+	m, err := Parse(`SNMPv2-TC DEFINITIONS ::= BEGIN
+		DisplayString ::= TEXTUAL-CONVENTION
+			DISPLAY-HINT "255a"
+			STATUS       current
+			DESCRIPTION  "Textual information."
+			REFERENCE    "RFC 854"
+			SYNTAX       OCTET STRING (SIZE (0..255))
+		MacAddress ::= OCTET STRING (SIZE (6))
+		IfEntry ::= SEQUENCE { ifIndex InterfaceIndex }
+	END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc, ok := m.Assignments[0].(*TextualConvention)
+	if !ok {
+		t.Fatalf("DisplayString is %T, want *TextualConvention", m.Assignments[0])
+	}
+	if tc.Plain || tc.DisplayHint != "255a" || tc.Status != "current" ||
+		tc.Description != "Textual information." || tc.Reference != "RFC 854" {
+		t.Errorf("DisplayString = %+v", tc)
+	}
+	if tc.Syntax.Base != "OCTET STRING" || !tc.Syntax.Size || len(tc.Syntax.Ranges) != 1 {
+		t.Errorf("Syntax = %+v, want OCTET STRING (SIZE (0..255))", tc.Syntax)
+	}
+
+	mac, ok := m.Assignments[1].(*TextualConvention)
+	if !ok || !mac.Plain || mac.Syntax.Base != "OCTET STRING" {
+		t.Errorf("MacAddress is %#v, want plain type assignment", m.Assignments[1])
+	}
+	if _, ok := m.Assignments[2].(*SequenceType); !ok {
+		t.Errorf("IfEntry is %T, want *SequenceType", m.Assignments[2])
+	}
+}
+
+func TestParse_syntax(t *testing.T) {
+	n := big.NewInt
+	tests := []struct {
+		name   string
+		syntax string
+		want   *Syntax
+	}{
+		{
+			name:   "enumeration",
+			syntax: `INTEGER { up(1), down(2), unknown(-1) }`,
+			want: &Syntax{
+				Base: "INTEGER",
+				NamedNumbers: []NamedNumber{
+					{Name: "up", Value: n(1)},
+					{Name: "down", Value: n(2)},
+					{Name: "unknown", Value: n(-1)},
+				},
+			},
+		},
+		{
+			name:   "range",
+			syntax: `Integer32 (1..2147483647)`,
+			want: &Syntax{
+				Base:   "Integer32",
+				Ranges: []Range{{Min: n(1), Max: n(2147483647)}},
+			},
+		},
+		{
+			name:   "negative range",
+			syntax: `INTEGER (-2147483648..-1 | 0)`,
+			want: &Syntax{
+				Base:   "INTEGER",
+				Ranges: []Range{{Min: n(-2147483648), Max: n(-1)}, {Min: n(0), Max: n(0)}},
+			},
+		},
+		{
+			name:   "size union",
+			syntax: `OCTET STRING (SIZE (0..255 | 1024))`,
+			want: &Syntax{
+				Base:   "OCTET STRING",
+				Size:   true,
+				Ranges: []Range{{Min: n(0), Max: n(255)}, {Min: n(1024), Max: n(1024)}},
+			},
+		},
+		{
+			name:   "bits",
+			syntax: `BITS { a(0), b(1) }`,
+			want: &Syntax{
+				Base:         "BITS",
+				NamedNumbers: []NamedNumber{{Name: "a", Value: n(0)}, {Name: "b", Value: n(1)}},
+			},
+		},
+		{
+			name:   "hex range",
+			syntax: `Unsigned32 ('00'H..'ff'h)`,
+			want: &Syntax{
+				Base:   "Unsigned32",
+				Ranges: []Range{{Min: n(0), Max: n(255)}},
+			},
+		},
+		{
+			name:   "tagged",
+			syntax: `[APPLICATION 6] IMPLICIT Counter64 (0..18446744073709551615)`,
+			want: &Syntax{
+				Base:     "Counter64",
+				Tag:      "APPLICATION 6",
+				Implicit: true,
+				Ranges:   []Range{{Min: n(0), Max: new(big.Int).SetUint64(18446744073709551615)}},
+			},
+		},
+		{
+			name:   "sequence of",
+			syntax: `SEQUENCE OF IfEntry`,
+			want: &Syntax{
+				Base: "SEQUENCE OF",
+				Of:   &Syntax{Pos: tokens.Position{Offset: 61, Line: 1, Column: 62}, Base: "IfEntry"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Parse(`A-MIB DEFINITIONS ::= BEGIN a OBJECT-TYPE SYNTAX ` + tt.syntax + ` ::= { b 1 } END`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := m.Assignments[0].(*ObjectType).Syntax
+			tt.want.Pos = tokens.Position{Offset: 49, Line: 1, Column: 50}
+			if got, want := dumpSyntax(got), dumpSyntax(tt.want); got != want {
+				t.Errorf("Syntax = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+// dumpSyntax formats s for comparison; big.Ints that are equal may not be
+// deeply equal.
+func dumpSyntax(s *Syntax) string {
+	if s == nil {
+		return "<nil>"
+	}
+	of := s.Of
+	s2 := *s
+	s2.Of = nil
+	return fmt.Sprintf("%+v of %s (at %d)", s2, dumpSyntax(of), s.Pos.Offset)
+}
+
+func TestSyntax_InRange(t *testing.T) {
+	s := &Syntax{Ranges: []Range{
+		{Min: big.NewInt(-5), Max: big.NewInt(-1)},
+		{Min: big.NewInt(10), Max: big.NewInt(10)},
+	}}
+	for v, want := range map[int64]bool{-6: false, -5: true, -1: true, 0: false, 10: true, 11: false} {
+		if got := s.InRange(big.NewInt(v)); got != want {
+			t.Errorf("InRange(%d) = %v, want %v", v, got, want)
+		}
+	}
+	if !(&Syntax{}).InRange(big.NewInt(1)) {
+		t.Errorf("InRange() without constraint = false, want true")
+	}
+}
+
+func TestParse_sequence(t *testing.T) {
+	m, err := Parse(smallMIB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq, ok := m.Assignments[4].(*SequenceType)
+	if !ok {
+		t.Fatalf("SmallEntry is %T, want *SequenceType", m.Assignments[4])
+	}
+	var cols []string
+	for _, c := range seq.Columns {
+		cols = append(cols, c.Name+" "+c.Syntax.Base)
+	}
+	wantCols := []string{"smallIndex SmallIndex", "smallDescr DisplayString"}
+	if !reflect.DeepEqual(cols, wantCols) {
+		t.Errorf("Columns = %v, want %v", cols, wantCols)
+	}
+	if got, want := seq.Columns[1].Pos, (tokens.Position{Offset: 702, Line: 35, Column: 2}); got != want {
+		t.Errorf("Columns[1].Pos = %v, want %v", got, want)
+	}
+	if seq.Table != m.Assignments[2] {
+		t.Errorf("Table = %v, want smallTable", seq.Table)
+	}
+	if seq.Entry != m.Assignments[3] {
+		t.Errorf("Entry = %v, want smallEntry", seq.Entry)
+	}
+
+	table := m.Assignments[2].(*ObjectType)
+	if table.Syntax.Base != "SEQUENCE OF" || table.Syntax.Of.Base != "SmallEntry" {
+		t.Errorf("smallTable Syntax = %+v, want SEQUENCE OF SmallEntry", table.Syntax)
+	}
+}
+
+func TestParse_macro(t *testing.T) {
+	m, err := Parse(`RFC-1215 DEFINITIONS ::= BEGIN
+		TRAP-TYPE MACRO ::= BEGIN
+			TYPE NOTATION ::= "ENTERPRISE" value (enterprise OBJECT IDENTIFIER)
+			VALUE NOTATION ::= value (VALUE INTEGER)
+		END
+		Counter ::= [APPLICATION 1] IMPLICIT INTEGER (0..4294967295)
+	END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Assignments[0].(*MacroDefinition); !ok {
+		t.Errorf("TRAP-TYPE is %T, want *MacroDefinition", m.Assignments[0])
+	}
+	a, ok := m.Assignments[1].(*TextualConvention)
+	if !ok || !a.Plain {
+		t.Fatalf("Counter is %#v, want plain type assignment", m.Assignments[1])
+	}
+	if a.Syntax.Tag != "APPLICATION 1" || !a.Syntax.Implicit || a.Syntax.Base != "INTEGER" {
+		t.Errorf("Counter = %+v, want [APPLICATION 1] IMPLICIT INTEGER", a.Syntax)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "empty",
+			input: ``,
+			want:  "1:1: expected module name, found EOF",
+		},
+		{
+			name:  "missing definitions",
+			input: `A-MIB ::= BEGIN END`,
+			want:  `1:7: expected DEFINITIONS, found "::="`,
+		},
+		{
+			name:  "missing end",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT IDENTIFIER ::= { b 1 }`,
+			want:  "1:60: unexpected EOF; module A-MIB is missing END",
+		},
+		{
+			name:  "missing from",
+			input: `A-MIB DEFINITIONS ::= BEGIN IMPORTS a, b; END`,
+			want:  "1:41: imported symbols [a b] are missing FROM",
+		},
+		{
+			name:  "lexer error",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT-TYPE DESCRIPTION "abc`,
+			want:  "1:55: unterminated quoted string",
+		},
+		{
+			name:  "object type without oid",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT-TYPE SYNTAX INTEGER ::= 1 END`,
+			want:  `1:62: expected object identifier value of a, found "1"`,
+		},
+		{
+			name:  "bad last updated",
+			input: `A-MIB DEFINITIONS ::= BEGIN a MODULE-IDENTITY LAST-UPDATED "2000061400Z" ::= { b 1 } END`,
+			want:  `1:60: invalid ExtUTCTime <"2000061400Z">`,
+		},
+		{
+			name:  "bad trap number",
+			input: `A-MIB DEFINITIONS ::= BEGIN a TRAP-TYPE ENTERPRISE b ::= 4294967296 END`,
+			want:  `1:58: invalid trap number "4294967296"`,
+		},
+		{
+			name:  "textual convention without syntax",
+			input: `A-MIB DEFINITIONS ::= BEGIN A ::= TEXTUAL-CONVENTION STATUS current END`,
+			want:  `1:69: expected SYNTAX in TEXTUAL-CONVENTION, found <END>`,
+		},
+		{
+			name:  "bad range",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT-TYPE SYNTAX INTEGER (1..MAX) ::= { b 1 } END`,
+			want:  `1:62: expected number, found "MAX"`,
+		},
+		{
+			name:  "truncated object type",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT-TYPE SYNTAX INTEGER END`,
+			want:  `1:58: expected ::= in definition of a, found <END>`,
+		},
+		{
+			name:  "missing value",
+			input: `A-MIB DEFINITIONS ::= BEGIN a INTEGER ::= END`,
+			want:  `1:43: expected value of a, found <END>`,
+		},
+		{
+			name:  "bad oid",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT IDENTIFIER ::= { b "c" } END`,
+			want:  `1:57: unexpected <"c"> in object identifier`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if _, ok := err.(*Error); !ok {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Parse() error = %q, want %q", got, tt.want)
+			}
+			var serr *tokens.SyntaxError
+			unexpected := errors.As(err, &serr) && serr.Kind == tokens.UnexpectedToken
+			if want := strings.Contains(tt.want, ": expected "); unexpected != want {
+				t.Errorf("Parse() error wraps UnexpectedToken = %v, want %v", unexpected, want)
+			}
+		})
+	}
+}
+
+func TestParse_syntaxError(t *testing.T) {
+	_, err := Parse("A-MIB DEFINITIONS ::= BEGIN\n\ta \x80 END")
+	var serr *tokens.SyntaxError
+	if !errors.As(err, &serr) {
+		t.Fatalf("Parse() error = %v, want *tokens.SyntaxError", err)
+	}
+	if serr.Kind != tokens.InvalidCharacter || serr.Char != 0x80 {
+		t.Errorf("Parse() error = %+v, want invalid character 0x80", serr)
+	}
+}
+
+func TestParse_listErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "missing comma",
+			input: `A-MIB DEFINITIONS ::= BEGIN A ::= SEQUENCE { a INTEGER b INTEGER } END`,
+			want:  `1:56: expected , or }, found "b"`,
+		},
+		{
+			name:  "leading commas",
+			input: `A-MIB DEFINITIONS ::= BEGIN A ::= SEQUENCE { , , a INTEGER } END`,
+			want:  `1:46: expected column name, found ","`,
+		},
+		{
+			name:  "trailing comma",
+			input: `A-MIB DEFINITIONS ::= BEGIN A ::= SEQUENCE { a INTEGER, } END`,
+			want:  `1:57: expected column name, found "}"`,
+		},
+		{
+			name:  "index missing comma",
+			input: `A-MIB DEFINITIONS ::= BEGIN a OBJECT-TYPE INDEX { b c } ::= { d 1 } END`,
+			want:  `1:53: expected , or }, found "c"`,
+		},
+		{
+			name:  "objects leading comma",
+			input: `A-MIB DEFINITIONS ::= BEGIN a NOTIFICATION-TYPE OBJECTS { , b } ::= { d 1 } END`,
+			want:  `1:59: expected object name, found ","`,
+		},
+		{
+			name:  "named numbers missing comma",
+			input: `A-MIB DEFINITIONS ::= BEGIN A ::= INTEGER { up(1) down(2) } END`,
+			want:  `1:51: expected , or }, found "down"`,
+		},
+		{
+			name:  "exports missing comma",
+			input: `A-MIB DEFINITIONS ::= BEGIN EXPORTS a b; END`,
+			want:  `1:39: expected , or ;, found "b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("Parse() error = %v, want %q", err, tt.want)
+			}
+			var serr *tokens.SyntaxError
+			if !errors.As(err, &serr) || serr.Kind != tokens.UnexpectedToken {
+				t.Errorf("Parse() error = %v, want UnexpectedToken", err)
+			}
+		})
+	}
+}
+
+func TestParse_encoding(t *testing.T) {
+	src := "\xEF\xBB\xBFA-MIB DEFINITIONS ::= BEGIN\n" +
+		"a OBJECT-TYPE DESCRIPTION \"caf\xE9\" ::= { b 1 }\n" +
+		"END\n"
+	m, err := Parse(src, tokens.SourceEncoding(tokens.Latin1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := m.Assignments[0].(*ObjectType)
+	if got, want := a.Description, "café"; got != want {
+		t.Errorf("DESCRIPTION = %q, want %q", got, want)
+	}
+}
+
+func TestParse_sppi(t *testing.T) {
+	m, err := Parse(`FRAMEWORK-PIB PIB-DEFINITIONS ::= BEGIN
+		frwkBasePibClasses OBJECT IDENTIFIER ::= { frameworkPib 1 }
+	END`, tokens.UseDialect(tokens.SPPI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Name, "FRAMEWORK-PIB"; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+package tokens
+
+import "unsafe"
+
+// NewBytesLexer creates a new scanner for the input bytes without copying
+// them; the values of the tokens share memory with input, so input must
+// not be modified while the Lexer or its tokens are in use. Scanning does
+// not allocate unless tokens keep trivia or strings are transcoded.
+func NewBytesLexer(input []byte, opts ...Option) *Lexer {
+	return NewLexer(bytesString(input), opts...)
+}
+
+// bytesString returns a string sharing memory with b.
+func bytesString(b []byte) string {
+	return *(*string)(unsafe.Pointer(&b))
+}
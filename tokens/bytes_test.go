@@ -0,0 +1,33 @@
+package tokens
+
+import "testing"
+
+func TestNewBytesLexer(t *testing.T) {
+	want := NewLexer(rfc1215)
+	got := NewBytesLexer([]byte(rfc1215))
+	for {
+		w, g := want.NextToken(), got.NextToken()
+		if g != w {
+			t.Fatalf("NextToken() = %+v, want %+v", g, w)
+		}
+		if w.Typ == EOF || w.Typ == Error {
+			break
+		}
+	}
+}
+
+func TestNewBytesLexer_allocs(t *testing.T) {
+	input := []byte(rfc1215)
+	allocs := testing.AllocsPerRun(100, func() {
+		lexer := NewBytesLexer(input)
+		for {
+			token := lexer.NextToken()
+			if token.Typ == EOF || token.Typ == Error {
+				break
+			}
+		}
+	})
+	if allocs > 1 { // the Lexer itself
+		t.Errorf("lexing allocated %v times, want 1", allocs)
+	}
+}
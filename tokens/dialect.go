@@ -0,0 +1,106 @@
+package tokens
+
+// Dialect selects the words that are keywords. A word that is not a
+// keyword in the dialect is a reference, e.g. TRAP-TYPE is a keyword in
+// SMIv1 but a type reference in SMIv2.
+type Dialect int
+
+const (
+	// DefaultDialect has the keywords of the net-snmp C parser, which
+	// mixes SMIv1, SMIv2 and its own names such as OBJECTSYNTAX.
+	DefaultDialect Dialect = iota
+	// SMIv1 has the keywords of RFC 1155, RFC 1212 and RFC 1215.
+	SMIv1
+	// SMIv2 has the keywords of RFC 2578, RFC 2579 and RFC 2580.
+	SMIv2
+	// SPPI has the keywords of the policy information base SMI of
+	// RFC 3159.
+	SPPI
+)
+
+var sppiLexemes = map[string]TokenType{
+	"PIB-DEFINITIONS":    PibDefinitions,
+	"PIB-ACCESS":         PibAccess,
+	"PIB-REFERENCES":     PibReferences,
+	"PIB-TAG":            PibTag,
+	"PIB-INDEX":          PibIndex,
+	"PIB-MIN-ACCESS":     PibMinAccess,
+	"EXTENDS":            Extends,
+	"UNIQUENESS":         Uniqueness,
+	"INSTALL-ERRORS":     InstallErrors,
+	"SUBJECT-CATEGORIES": SubjectCategories,
+	"INTEGER64":          Integer64,
+	"UNSIGNED64":         Unsigned64,
+	"INSTALL":            Install,
+	"NOTIFY":             Notify,
+	"INSTALL-NOTIFY":     InstallNotify,
+	"REPORT-ONLY":        ReportOnly,
+}
+
+// asn1Words are keywords in every dialect.
+var asn1Words = []string{
+	"DEFINITIONS", "BEGIN", "END", "IMPORTS", "EXPORTS", "FROM", "MACRO",
+	"OBJECT", "IDENTIFIER", "OCTET", "BIT", "SEQUENCE", "OF", "CHOICE",
+	"INTEGER", "NULL", "IMPLICIT", "SIZE",
+}
+
+var smiv1Words = []string{
+	"OBJECT-TYPE", "SYNTAX", "ACCESS", "STATUS", "DESCRIPTION", "REFERENCE",
+	"INDEX", "DEFVAL", "TRAP-TYPE", "ENTERPRISE", "VARIABLES",
+	"READ-ONLY", "READ-WRITE", "WRITE-ONLY", "NOT-ACCESSIBLE",
+	"MANDATORY", "OPTIONAL", "OBSOLETE", "DEPRECATED",
+	"COUNTER", "GAUGE", "TIMETICKS", "IPADDRESS", "NETWORKADDRESS", "OPAQUE",
+}
+
+// smiWords are the SMIv2 keywords shared with SPPI.
+var smiWords = []string{
+	"MODULE-IDENTITY", "LAST-UPDATED", "ORGANIZATION", "CONTACT-INFO",
+	"DESCRIPTION", "REVISION", "OBJECT-IDENTITY", "STATUS", "REFERENCE",
+	"OBJECT-TYPE", "SYNTAX", "UNITS", "INDEX", "IMPLIED",
+	"AUGMENTS", "DEFVAL", "OBJECTS", "TEXTUAL-CONVENTION", "DISPLAY-HINT",
+	"OBJECT-GROUP", "MODULE-COMPLIANCE", "MODULE", "MANDATORY-GROUPS",
+	"GROUP", "WRITE-SYNTAX", "BITS", "INTEGER32",
+	"UNSIGNED32", "COUNTER32", "COUNTER64", "GAUGE32", "TIMETICKS",
+	"IPADDRESS", "OPAQUE", "CURRENT", "DEPRECATED", "OBSOLETE",
+	"READ-ONLY", "READ-WRITE", "READ-CREATE", "NOT-ACCESSIBLE",
+}
+
+// smiv2Words are the SMIv2 keywords not in SPPI, which has PIB-ACCESS and
+// PIB-MIN-ACCESS in place of MAX-ACCESS and MIN-ACCESS.
+var smiv2Words = []string{
+	"MAX-ACCESS", "MIN-ACCESS", "NOTIFICATION-TYPE", "NOTIFICATION-GROUP", "NOTIFICATIONS",
+	"AGENT-CAPABILITIES", "PRODUCT-RELEASE", "SUPPORTS", "INCLUDES",
+	"VARIATION", "CREATION-REQUIRES", "ACCESS", "NOT-IMPLEMENTED",
+	"WRITE-ONLY", "ACCESSIBLE-FOR-NOTIFY",
+}
+
+var dialects = map[Dialect]map[string]TokenType{
+	DefaultDialect: lexemes,
+	SMIv1:          keywords(asn1Words, smiv1Words),
+	SMIv2:          keywords(asn1Words, smiWords, smiv2Words),
+	SPPI:           keywords(asn1Words, smiWords, sppiWords()),
+}
+
+// sppiWords returns the keywords only in SPPI.
+func sppiWords() []string {
+	words := make([]string, 0, len(sppiLexemes))
+	for word := range sppiLexemes {
+		words = append(words, word)
+	}
+	return words
+}
+
+// keywords builds a keyword table from lists of words.
+func keywords(lists ...[]string) map[string]TokenType {
+	m := map[string]TokenType{}
+	for _, words := range lists {
+		for _, word := range words {
+			typ, ok := lexemes[word]
+			if !ok {
+				typ = sppiLexemes[word]
+			}
+			m[word] = typ
+		}
+	}
+	return m
+}
@@ -0,0 +1,72 @@
+package tokens
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUseDialect(t *testing.T) {
+	const input = "TRAP-TYPE MODULE-IDENTITY OBJECTSYNTAX PIB-ACCESS install-notify OBJECT-TYPE MAX-ACCESS MIN-ACCESS PIB-MIN-ACCESS"
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    []TokenType
+	}{
+		{
+			name:    "default",
+			dialect: DefaultDialect,
+			want:    []TokenType{TrapType, ModuleIdentify, Objsyntax, TypeReference, ValueReference, ObjType, Access, MinAccess, TypeReference, EOF},
+		},
+		{
+			name:    "smiv1",
+			dialect: SMIv1,
+			want:    []TokenType{TrapType, TypeReference, TypeReference, TypeReference, ValueReference, ObjType, TypeReference, TypeReference, TypeReference, EOF},
+		},
+		{
+			name:    "smiv2",
+			dialect: SMIv2,
+			want:    []TokenType{TypeReference, ModuleIdentify, TypeReference, TypeReference, ValueReference, ObjType, Access, MinAccess, TypeReference, EOF},
+		},
+		{
+			name:    "sppi",
+			dialect: SPPI,
+			want:    []TokenType{TypeReference, ModuleIdentify, TypeReference, PibAccess, InstallNotify, ObjType, TypeReference, TypeReference, PibMinAccess, EOF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(input, UseDialect(tt.dialect))
+			var got []TokenType
+			for {
+				tk := lexer.NextToken()
+				got = append(got, tk.Typ)
+				if tk.Typ == EOF || tk.Typ == Error {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokens = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseDialect_unknown(t *testing.T) {
+	lexer := NewLexer("OBJECTSYNTAX", UseDialect(SMIv1), UseDialect(Dialect(99)))
+	if got := lexer.NextToken().Typ; got != Objsyntax {
+		t.Errorf("NextToken() = %v, want Objsyntax from DefaultDialect", got)
+	}
+}
+
+func Test_dialects(t *testing.T) {
+	for d, keywords := range dialects {
+		for word, typ := range keywords {
+			if typ <= Keyword {
+				t.Errorf("dialect %d keyword %s has type %d", d, word, typ)
+			}
+			if len(word) > len(Lexer{}.label) {
+				t.Errorf("dialect %d keyword %s is longer than the label buffer", d, word)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package tokens
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of the input.
+type Encoding int
+
+const (
+	// UTF8 input is scanned as is; this is the default.
+	UTF8 Encoding = iota
+	// Latin1 is ISO 8859-1.
+	Latin1
+	// Windows1252 is the Windows code page that extends Latin1 with
+	// characters such as smart quotes and dashes in 0x80-0x9F.
+	Windows1252
+)
+
+// bom is the UTF-8 encoded byte order mark.
+const bom = "\xEF\xBB\xBF"
+
+// windows1252 maps 0x80-0x9F to runes; unused bytes map as in Latin1.
+var windows1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+// decode transcodes s from e to UTF-8.
+func (e Encoding) decode(s string) string {
+	if e == UTF8 {
+		return s
+	}
+	i := 0
+	for i < len(s) && s[i] <= maxASCII {
+		i++
+	}
+	if i == len(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + len(s)/2)
+	b.WriteString(s[:i])
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c <= maxASCII:
+			b.WriteByte(c)
+		case e == Windows1252 && c < 0xA0:
+			b.WriteRune(windows1252[c-0x80])
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
+
+// lexBOM skips a byte order mark at the start of the input.
+func lexBOM(l *Lexer) (stateFn, Token) {
+	l.fill(len(bom))
+	if strings.HasPrefix(l.input[l.pos:], bom) {
+		l.pos += Pos(len(bom))
+		l.lineStart = l.base + l.pos
+		l.ignore()
+	}
+	return lexSpace, Token{}
+}
+
+// unicodeSpace returns the width of a UTF-8 encoded NEL or no-break space
+// at pos, or 0.
+func (l *Lexer) unicodeSpace() Pos {
+	if l.enc != UTF8 || !l.fill(2) {
+		return 0
+	}
+	switch r, n := utf8.DecodeRuneInString(l.input[l.pos:]); r {
+	case '\u0085', '\u00A0':
+		return Pos(n)
+	}
+	return 0
+}
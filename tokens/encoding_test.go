@@ -0,0 +1,83 @@
+package tokens
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSourceEncoding(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		enc   Encoding
+		want  []Token
+	}{
+		{
+			name:  "utf-8 bom",
+			input: bom + "a\nb",
+			want: []Token{
+				{Typ: ValueReference, Val: "a", Start: Position{3, 1, 1}, End: Position{4, 1, 2}},
+				{Typ: ValueReference, Val: "b", Start: Position{5, 2, 1}, End: Position{6, 2, 2}},
+				{Typ: EOF, Start: Position{6, 2, 2}, End: Position{6, 2, 2}},
+			},
+		},
+		{
+			name:  "utf-8 string",
+			input: "\"caf\xC3\xA9 \xE2\x80\x94\"",
+			want: []Token{
+				{Typ: Quotestring, Val: "\"café —\"", Start: Position{0, 1, 1}, End: Position{11, 1, 12}},
+				{Typ: EOF, Start: Position{11, 1, 12}, End: Position{11, 1, 12}},
+			},
+		},
+		{
+			name:  "utf-8 no-break space",
+			input: "a\xC2\xA0b",
+			want: []Token{
+				{Typ: ValueReference, Val: "a", Start: Position{0, 1, 1}, End: Position{1, 1, 2}},
+				{Typ: ValueReference, Val: "b", Start: Position{3, 1, 4}, End: Position{4, 1, 5}},
+				{Typ: EOF, Start: Position{4, 1, 5}, End: Position{4, 1, 5}},
+			},
+		},
+		{
+			name:  "latin-1 string",
+			input: "\"caf\xE9\"",
+			enc:   Latin1,
+			want: []Token{
+				{Typ: Quotestring, Val: "\"café\"", Start: Position{0, 1, 1}, End: Position{6, 1, 7}},
+				{Typ: EOF, Start: Position{6, 1, 7}, End: Position{6, 1, 7}},
+			},
+		},
+		{
+			name:  "windows-1252 string",
+			input: "\"\x93quoted\x94 \x96 caf\xE9\"",
+			enc:   Windows1252,
+			want: []Token{
+				{Typ: Quotestring, Val: "\"“quoted” – café\"", Start: Position{0, 1, 1}, End: Position{17, 1, 18}},
+				{Typ: EOF, Start: Position{17, 1, 18}, End: Position{17, 1, 18}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input, SourceEncoding(tt.enc))
+			var got []Token
+			for {
+				tk := lexer.NextToken()
+				got = append(got, tk)
+				if tk.Typ == EOF || tk.Typ == Error {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokens = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceEncoding_bomTrivia(t *testing.T) {
+	tk := NewLexer(bom+"a", KeepTrivia()).NextToken()
+	if tk.Leading != bom || tk.Val != "a" {
+		t.Errorf("NextToken() = %+v, want a with leading byte order mark", tk)
+	}
+}
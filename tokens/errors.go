@@ -0,0 +1,78 @@
+package tokens
+
+import "fmt"
+
+// ErrorKind classifies a SyntaxError.
+type ErrorKind uint
+
+const (
+	// UnterminatedString is a quoted string missing its closing quote.
+	UnterminatedString ErrorKind = iota + 1
+	// UnterminatedLiteral is a 'B or 'H literal missing its closing quote.
+	UnterminatedLiteral
+	// InvalidCharacter is a byte that cannot begin any token.
+	InvalidCharacter
+	// HexDigitInBinary is a 'B literal containing hex digits; strict only.
+	HexDigitInBinary
+	// InvalidLiteralDigit is a 'B or 'H literal containing a byte that is
+	// not a hex digit; strict only.
+	InvalidLiteralDigit
+	// UnknownRadix is a literal with a suffix other than B or H; strict only.
+	UnknownRadix
+	// MissingRadix is a literal without a B or H suffix; strict only.
+	MissingRadix
+	// UnexpectedToken is a token other than the one expected by
+	// Stream.Expect.
+	UnexpectedToken
+	// UnderscoreInIdentifier is an identifier containing an underscore,
+	// which RFC 2578 does not allow; strict or lenient only.
+	UnderscoreInIdentifier
+	// LongIdentifier is an identifier longer than the 64 characters
+	// allowed by RFC 2578; strict or lenient only.
+	LongIdentifier
+	// InvalidHyphen is an identifier ending in a hyphen or containing two
+	// hyphens in a row; strict or lenient only.
+	InvalidHyphen
+	// InvalidIdentifier is a word that does not begin with a letter or is
+	// not a number, such as `$`; strict or lenient only.
+	InvalidIdentifier
+	// CommentInString is a quoted string containing `--`, which some MIB
+	// compilers take for the start of a comment. It is always a warning.
+	CommentInString
+)
+
+var errorKinds = [...]string{
+	UnterminatedString:  "unterminated string",
+	UnterminatedLiteral: "unterminated literal",
+	InvalidCharacter:    "invalid character",
+	HexDigitInBinary:    "hex digit in binary string",
+	InvalidLiteralDigit: "invalid literal digit",
+	UnknownRadix:        "unknown radix",
+	MissingRadix:        "missing radix",
+	UnexpectedToken:     "unexpected token",
+
+	UnderscoreInIdentifier: "underscore in identifier",
+	LongIdentifier:         "identifier too long",
+	InvalidHyphen:          "invalid hyphen in identifier",
+	InvalidIdentifier:      "invalid identifier",
+	CommentInString:        "comment in string",
+}
+
+func (k ErrorKind) String() string {
+	if int(k) < len(errorKinds) && errorKinds[k] != "" {
+		return errorKinds[k]
+	}
+	return fmt.Sprintf("ErrorKind(%d)", uint(k))
+}
+
+// SyntaxError describes a problem found in the input by the Lexer.
+type SyntaxError struct {
+	Kind ErrorKind
+	Char byte     // offending byte; 0xFF for the end of input
+	Pos  Position // start of the offending token
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
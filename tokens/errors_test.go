@@ -0,0 +1,311 @@
+package tokens
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLexer_Err(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *SyntaxError
+	}{
+		{
+			name:  "no error",
+			input: "a ::= b",
+		},
+		{
+			name:  "unterminated string",
+			input: "a \"b",
+			want: &SyntaxError{
+				Kind: UnterminatedString,
+				Char: eof,
+				Pos:  Position{Offset: 2, Line: 1, Column: 3},
+				Msg:  "unterminated quoted string",
+			},
+		},
+		{
+			name:  "unterminated literal",
+			input: "\n'01",
+			want: &SyntaxError{
+				Kind: UnterminatedLiteral,
+				Char: eof,
+				Pos:  Position{Offset: 1, Line: 2, Column: 1},
+				Msg:  "unterminated literal string",
+			},
+		},
+		{
+			name:  "invalid character",
+			input: "a \x80",
+			want: &SyntaxError{
+				Kind: InvalidCharacter,
+				Char: 0x80,
+				Pos:  Position{Offset: 2, Line: 1, Column: 3},
+				Msg:  "unrecognized character: U+0080",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			for {
+				tk := lexer.NextToken()
+				if tk.Typ == EOF || tk.Typ == Error {
+					break
+				}
+			}
+			err := lexer.Err()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Err() = %v, want nil", err)
+				}
+				return
+			}
+			var serr *SyntaxError
+			if !errors.As(err, &serr) {
+				t.Fatalf("Err() = %v, want *SyntaxError", err)
+			}
+			if *serr != *tt.want {
+				t.Errorf("Err() = %+v, want %+v", serr, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexer_RecoverErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []TokenType
+		kinds []ErrorKind
+	}{
+		{
+			name:  "emoji between references",
+			input: "a 😀 b",
+			want:  []TokenType{ValueReference, ValueReference, EOF},
+			kinds: []ErrorKind{InvalidCharacter},
+		},
+		{
+			name:  "stray bytes in two places",
+			input: "a\x01b \x93c\x94",
+			want:  []TokenType{ValueReference, ValueReference, ValueReference, EOF},
+			kinds: []ErrorKind{InvalidCharacter, InvalidCharacter, InvalidCharacter},
+		},
+		{
+			name:  "unterminated string",
+			input: `a "b`,
+			want:  []TokenType{ValueReference, EOF},
+			kinds: []ErrorKind{UnterminatedString},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input, RecoverErrors())
+			var got []TokenType
+			for {
+				tk := lexer.NextToken()
+				got = append(got, tk.Typ)
+				if tk.Typ == EOF || tk.Typ == Error {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokens = %v, want %v", got, tt.want)
+			}
+			if err := lexer.Err(); err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+			var kinds []ErrorKind
+			for _, d := range lexer.Diagnostics() {
+				kinds = append(kinds, d.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tt.kinds) {
+				t.Errorf("Diagnostics() kinds = %v, want %v", kinds, tt.kinds)
+			}
+		})
+	}
+}
+
+func TestLexer_Strict(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []TokenType
+		err   *SyntaxError
+	}{
+		{
+			name:  "binary",
+			input: `'0101'B`,
+			want:  []TokenType{Binary, EOF},
+		},
+		{
+			name:  "hex",
+			input: `'0aF9'h`,
+			want:  []TokenType{Hex, EOF},
+		},
+		{
+			name:  "hex digit in binary",
+			input: `'0102'B`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: HexDigitInBinary, Char: '2', Pos: Position{0, 1, 1}, Msg: `hex digit '2' in binary string`},
+		},
+		{
+			name:  "invalid digit in binary",
+			input: `'label'b`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: InvalidLiteralDigit, Char: 'l', Pos: Position{0, 1, 1}, Msg: `invalid digit 'l' in binary string`},
+		},
+		{
+			name:  "invalid digit after hex digit in binary",
+			input: `'2G'B`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: InvalidLiteralDigit, Char: 'G', Pos: Position{0, 1, 1}, Msg: `invalid digit 'G' in binary string`},
+		},
+		{
+			name:  "invalid digit in hex",
+			input: `'0G'H`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: InvalidLiteralDigit, Char: 'G', Pos: Position{0, 1, 1}, Msg: `invalid digit 'G' in hex string`},
+		},
+		{
+			name:  "unknown radix",
+			input: ` '01'u`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: UnknownRadix, Char: 'u', Pos: Position{1, 1, 2}, Msg: `unknown radix 'u' in literal string; want B or H`},
+		},
+		{
+			name:  "missing radix",
+			input: `'01' )`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: MissingRadix, Char: ' ', Pos: Position{0, 1, 1}, Msg: `literal string is missing B or H suffix`},
+		},
+		{
+			name:  "missing radix at eof",
+			input: `''`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: MissingRadix, Char: eof, Pos: Position{0, 1, 1}, Msg: `literal string is missing B or H suffix`},
+		},
+		{ // hyphens are allowed for module names and SMIv1 descriptors
+			name:  "identifiers",
+			input: `ifIndex IfEntry if-Index`,
+			want:  []TokenType{ValueReference, TypeReference, ValueReference, EOF},
+		},
+		{
+			name:  "underscore",
+			input: `a if_Index`,
+			want:  []TokenType{ValueReference, Error},
+			err:   &SyntaxError{Kind: UnderscoreInIdentifier, Char: '_', Pos: Position{2, 1, 3}, Msg: `underscore in identifier "if_Index"`},
+		},
+		{
+			name:  "trailing hyphen",
+			input: `ifIndex-`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: InvalidHyphen, Char: '-', Pos: Position{0, 1, 1}, Msg: `identifier "ifIndex-" ends in a hyphen`},
+		},
+		{
+			name:  "consecutive hyphens",
+			input: `if--Index`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: InvalidHyphen, Char: '-', Pos: Position{0, 1, 1}, Msg: `consecutive hyphens in identifier "if--Index"`},
+		},
+		{
+			name:  "dollar",
+			input: `$`,
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: InvalidIdentifier, Char: '$', Pos: Position{0, 1, 1}, Msg: `invalid identifier "$"; want a letter first`},
+		},
+		{
+			name:  "too long",
+			input: strings.Repeat("a", 65),
+			want:  []TokenType{Error},
+			err:   &SyntaxError{Kind: LongIdentifier, Char: 'a', Pos: Position{0, 1, 1}, Msg: `identifier "` + strings.Repeat("a", 65) + `" is longer than 64 characters`},
+		},
+		{
+			name:  "64 characters",
+			input: strings.Repeat("a", 64),
+			want:  []TokenType{ValueReference, EOF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input, Strict())
+			var got []TokenType
+			for {
+				tk := lexer.NextToken()
+				got = append(got, tk.Typ)
+				if tk.Typ == EOF || tk.Typ == Error {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokens = %v, want %v", got, tt.want)
+			}
+			if tt.err == nil {
+				if err := lexer.Err(); err != nil {
+					t.Errorf("Err() = %v, want nil", err)
+				}
+				return
+			}
+			if err, ok := lexer.Err().(*SyntaxError); !ok || *err != *tt.err {
+				t.Errorf("Err() = %+v, want %+v", lexer.Err(), tt.err)
+			}
+		})
+	}
+}
+
+func TestLexer_StrictRecover(t *testing.T) {
+	lexer := NewLexer(`DEFVAL { '0102'B } x '01' y`, Strict(), RecoverErrors())
+	var got []TokenType
+	for {
+		tk := lexer.NextToken()
+		got = append(got, tk.Typ)
+		if tk.Typ == EOF || tk.Typ == Error {
+			break
+		}
+	}
+	want := []TokenType{Defval, LeftBracket, RightBracket, ValueReference, ValueReference, EOF}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokens = %v, want %v", got, want)
+	}
+	if n := len(lexer.Diagnostics()); n != 2 {
+		t.Errorf("len(Diagnostics()) = %d, want 2", n)
+	}
+}
+
+func TestLexer_Lenient(t *testing.T) {
+	input := "a_b " + strings.Repeat("x", 70) + " c- $ DESCRIPTION \"-- not a comment\" 1 -2"
+	lexer := NewLexer(input, Lenient())
+	var got []TokenType
+	for {
+		tk := lexer.NextToken()
+		got = append(got, tk.Typ)
+		if tk.Typ == EOF || tk.Typ == Error {
+			break
+		}
+	}
+	want := []TokenType{ValueReference, ValueReference, ValueReference, Label, Description, Quotestring, Number, NegativeNumber, EOF}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokens = %v, want %v", got, want)
+	}
+	var kinds []ErrorKind
+	for _, w := range lexer.Warnings() {
+		kinds = append(kinds, w.Kind)
+	}
+	wantKinds := []ErrorKind{UnderscoreInIdentifier, LongIdentifier, InvalidHyphen, InvalidIdentifier, CommentInString}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Errorf("Warnings() = %v, want %v", kinds, wantKinds)
+	}
+	if err := lexer.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	lexer = NewLexer(input)
+	for lexer.NextToken().Typ != EOF {
+	}
+	if w := lexer.Warnings(); len(w) != 0 {
+		t.Errorf("Warnings() without Lenient = %v, want none", w)
+	}
+}
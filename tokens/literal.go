@@ -0,0 +1,86 @@
+package tokens
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// splitLiteral returns the digits and radix of a literal such as '0A'H.
+func splitLiteral(lit string) (digits string, radix int, err error) {
+	end := strings.LastIndexByte(lit, '\'')
+	if len(lit) < 3 || lit[0] != '\'' || end != len(lit)-2 {
+		return "", 0, fmt.Errorf("malformed literal %q", lit)
+	}
+	digits = lit[1:end]
+	switch lit[len(lit)-1] {
+	case 'H', 'h':
+		radix = 16
+	case 'B', 'b':
+		radix = 2
+	default:
+		return "", 0, fmt.Errorf("unknown radix in literal %q", lit)
+	}
+	for i := 0; i < len(digits); i++ {
+		if digitVal(digits[i]) >= radix {
+			return "", 0, fmt.Errorf("invalid digit %q in literal %q", digits[i], lit)
+		}
+	}
+	return digits, radix, nil
+}
+
+// digitVal returns the value of the hex digit c or 16 if c is not a digit.
+func digitVal(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'f':
+		return int(c - 'a' + 10)
+	case 'A' <= c && c <= 'F':
+		return int(c - 'A' + 10)
+	}
+	return 16
+}
+
+// DecodeLiteral decodes a hex string such as '0A0B'H or a binary string such
+// as '1010'B into bytes. As in X.208, a value that is not a multiple of
+// eight bits is padded with trailing zero bits, so 'A'H and '1010'B are
+// both 0xA0.
+func DecodeLiteral(lit string) ([]byte, error) {
+	digits, radix, err := splitLiteral(lit)
+	if err != nil {
+		return nil, err
+	}
+	width := uint(4) // bits per digit
+	if radix == 2 {
+		width = 1
+	}
+	b := make([]byte, (uint(len(digits))*width+7)/8)
+	for i := 0; i < len(digits); i++ {
+		bit := uint(i) * width
+		b[bit/8] |= byte(digitVal(digits[i])) << (8 - width - bit%8)
+	}
+	return b, nil
+}
+
+// Bytes returns the decoded value of a Hex or Binary token.
+func (t Token) Bytes() ([]byte, error) {
+	if t.Typ != Hex && t.Typ != Binary {
+		return nil, fmt.Errorf("%s is not a hex or binary string", t)
+	}
+	return DecodeLiteral(t.Val)
+}
+
+// literalBigInt returns the numeric value of a hex or binary literal, which
+// unlike DecodeLiteral is not padded; 'A'H is 10.
+func literalBigInt(lit string) (*big.Int, error) {
+	digits, radix, err := splitLiteral(lit)
+	if err != nil {
+		return nil, err
+	}
+	if digits == "" {
+		return new(big.Int), nil
+	}
+	n, _ := new(big.Int).SetString(digits, radix)
+	return n, nil
+}
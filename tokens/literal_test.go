@@ -0,0 +1,76 @@
+package tokens
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeLiteral(t *testing.T) {
+	tests := []struct {
+		name    string
+		lit     string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "empty hex", lit: `''H`, want: []byte{}},
+		{name: "hex", lit: `'0A0B'H`, want: []byte{0x0A, 0x0B}},
+		{name: "lowercase hex", lit: `'fedcba'h`, want: []byte{0xFE, 0xDC, 0xBA}},
+		{name: "odd length hex", lit: `'ABC'H`, want: []byte{0xAB, 0xC0}},
+		{name: "empty binary", lit: `''B`, want: []byte{}},
+		{name: "binary", lit: `'0000000110000000'B`, want: []byte{0x01, 0x80}},
+		{name: "short binary", lit: `'101'b`, want: []byte{0xA0}},
+		{name: "nine bit binary", lit: `'111111111'B`, want: []byte{0xFF, 0x80}},
+		{name: "hex digit in binary", lit: `'12'B`, wantErr: true},
+		{name: "bad hex digit", lit: `'0G'H`, wantErr: true},
+		{name: "unknown radix", lit: `'01'U`, wantErr: true},
+		{name: "missing radix", lit: `'01'`, wantErr: true},
+		{name: "not a literal", lit: `01`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeLiteral(tt.lit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeLiteral() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("DecodeLiteral() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToken_literals(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		bytes []byte
+		num   int64
+	}{
+		{name: "hex", input: `'0A'H`, bytes: []byte{0x0A}, num: 10},
+		{name: "odd hex", input: `'A'h`, bytes: []byte{0xA0}, num: 10},
+		{name: "binary", input: `'1010'B`, bytes: []byte{0xA0}, num: 10},
+		{name: "empty", input: `''H`, bytes: []byte{}, num: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := NewLexer(tt.input).NextToken()
+			b, err := tk.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b, tt.bytes) {
+				t.Errorf("Bytes() = %x, want %x", b, tt.bytes)
+			}
+			n, err := tk.BigInt()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n.Int64() != tt.num {
+				t.Errorf("BigInt() = %v, want %v", n, tt.num)
+			}
+		})
+	}
+	if _, err := (Token{Typ: Quotestring, Val: `"a"`}).Bytes(); err == nil {
+		t.Error("Bytes() of a string succeeded")
+	}
+}
@@ -0,0 +1,40 @@
+package tokens
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// BigInt returns the value of a Number, NegativeNumber, Hex or Binary token.
+func (t Token) BigInt() (*big.Int, error) {
+	if t.Typ == Hex || t.Typ == Binary {
+		return literalBigInt(t.Val)
+	}
+	if t.Typ != Number && t.Typ != NegativeNumber {
+		return nil, fmt.Errorf("%s is not a number", t)
+	}
+	n, ok := new(big.Int).SetString(t.Val, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", t)
+	}
+	return n, nil
+}
+
+// Int64 returns the value of a Number or NegativeNumber token. It is an
+// error if the value does not fit in an int64.
+func (t Token) Int64() (int64, error) {
+	if t.Typ != Number && t.Typ != NegativeNumber {
+		return 0, fmt.Errorf("%s is not a number", t)
+	}
+	return strconv.ParseInt(t.Val, 10, 64)
+}
+
+// Uint64 returns the value of a Number token. It is an error if the value
+// does not fit in a uint64.
+func (t Token) Uint64() (uint64, error) {
+	if t.Typ != Number {
+		return 0, fmt.Errorf("%s is not an unsigned number", t)
+	}
+	return strconv.ParseUint(t.Val, 10, 64)
+}
@@ -0,0 +1,72 @@
+package tokens
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestToken_numbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		tk      Token
+		want    string
+		int64   int64
+		uint64  uint64
+		intErr  bool
+		uintErr bool
+	}{
+		{
+			name:   "number",
+			tk:     Token{Typ: Number, Val: "2147483647"},
+			want:   "2147483647",
+			int64:  2147483647,
+			uint64: 2147483647,
+		},
+		{
+			name:    "negative number",
+			tk:      Token{Typ: NegativeNumber, Val: "-1"},
+			want:    "-1",
+			int64:   -1,
+			uintErr: true,
+		},
+		{
+			name:   "larger than int64",
+			tk:     Token{Typ: Number, Val: "18446744073709551615"},
+			want:   "18446744073709551615",
+			intErr: true,
+			uint64: 18446744073709551615,
+		},
+		{
+			name:    "larger than uint64",
+			tk:      Token{Typ: Number, Val: "18446744073709551616"},
+			want:    "18446744073709551616",
+			intErr:  true,
+			uintErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := tt.tk.BigInt()
+			if err != nil {
+				t.Fatalf("BigInt() error = %v", err)
+			}
+			if want, _ := new(big.Int).SetString(tt.want, 10); n.Cmp(want) != 0 {
+				t.Errorf("BigInt() = %v, want %v", n, want)
+			}
+			i, err := tt.tk.Int64()
+			if (err != nil) != tt.intErr || i != tt.int64 && !tt.intErr {
+				t.Errorf("Int64() = %v, %v, want %v", i, err, tt.int64)
+			}
+			u, err := tt.tk.Uint64()
+			if (err != nil) != tt.uintErr || u != tt.uint64 && !tt.uintErr {
+				t.Errorf("Uint64() = %v, %v, want %v", u, err, tt.uint64)
+			}
+		})
+	}
+}
+
+func TestToken_BigInt_notNumber(t *testing.T) {
+	if _, err := (Token{Typ: Label, Val: "a"}).BigInt(); err == nil {
+		t.Error("BigInt() of a label succeeded")
+	}
+}
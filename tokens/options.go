@@ -0,0 +1,68 @@
+package tokens
+
+// Option configures a Lexer.
+type Option func(*Lexer)
+
+// RecoverErrors makes the Lexer skip input it cannot scan rather than
+// stopping with an Error token. Each problem is recorded and available
+// from Diagnostics once the scan reaches EOF.
+func RecoverErrors() Option {
+	return func(l *Lexer) {
+		l.recover = true
+	}
+}
+
+// Strict makes the Lexer report input that the C parser accepts but which
+// is not valid SMI, such as '12'B or '01'U, as errors. Identifiers must
+// begin with a letter, contain only letters, digits and non-consecutive
+// hyphens, not end in a hyphen and be at most 64 characters long.
+//
+// This is looser than RFC 2578, which does not allow hyphens in SMIv2
+// descriptors at all. The Lexer cannot tell descriptors from the module
+// names and SMIv1 descriptors such as mib-2 that may keep their hyphens,
+// so it accepts a single hyphen within any identifier.
+func Strict() Option {
+	return func(l *Lexer) {
+		l.strict = true
+	}
+}
+
+// Lenient makes the Lexer accept the identifier mistakes common in vendor
+// MIBs that Strict reports as errors, such as underscores, stray `$`
+// characters or a trailing hyphen, but record each as a warning available
+// from Warnings.
+func Lenient() Option {
+	return func(l *Lexer) {
+		l.lenient = true
+	}
+}
+
+// KeepTrivia makes the Lexer keep the spaces and comments between tokens
+// in their Leading and Trailing fields, so that concatenating the
+// Leading, Val and Trailing of every token through EOF reproduces the
+// input exactly; unless strings were transcoded with SourceEncoding.
+func KeepTrivia() Option {
+	return func(l *Lexer) {
+		l.trivia = true
+	}
+}
+
+// SourceEncoding sets the encoding of the input. Quoted strings are
+// transcoded to UTF-8; everything else in a MIB is ASCII.
+func SourceEncoding(e Encoding) Option {
+	return func(l *Lexer) {
+		l.enc = e
+	}
+}
+
+// UseDialect sets the keywords recognized by the Lexer; the default is
+// DefaultDialect, which is also used for an unknown Dialect.
+func UseDialect(d Dialect) Option {
+	return func(l *Lexer) {
+		keywords, ok := dialects[d]
+		if !ok {
+			keywords = dialects[DefaultDialect]
+		}
+		l.keywords = keywords
+	}
+}
@@ -0,0 +1,59 @@
+package tokens
+
+import "io"
+
+// readSize is the number of bytes requested from the reader at a time.
+const readSize = 4096
+
+// NewReaderLexer creates a new scanner reading its input from r. Only the
+// input of the token being scanned and the most recent read are held in
+// memory, so very large files are scanned without reading them whole.
+// The tokens are the same as from NewLexer on the whole input.
+func NewReaderLexer(r io.Reader, opts ...Option) *Lexer {
+	l := NewLexer("", opts...)
+	l.r = r
+	l.buf = make([]byte, 0, readSize)
+	return l
+}
+
+// fill reads from the reader until at least n bytes are available at pos,
+// returning false if the input ends first. Input before start has been
+// scanned, so it is discarded once it is over half of the window.
+func (l *Lexer) fill(n int) bool {
+	for int(l.pos)+n > len(l.input) {
+		if l.r == nil {
+			return false
+		}
+		if int(l.start) > len(l.buf)/2 {
+			k := copy(l.buf, l.buf[l.start:])
+			l.buf = l.buf[:k]
+			l.base += l.start
+			l.pos -= l.start
+			l.start = 0
+		}
+		if cap(l.buf)-len(l.buf) < readSize {
+			buf := make([]byte, len(l.buf), 2*cap(l.buf)+readSize)
+			copy(buf, l.buf)
+			l.buf = buf
+		}
+		k, err := l.r.Read(l.buf[len(l.buf) : len(l.buf)+readSize])
+		l.buf = l.buf[:len(l.buf)+k]
+		l.input = bytesString(l.buf)
+		if err != nil {
+			if err != io.EOF {
+				l.rerr = err
+			}
+			l.r = nil
+		}
+	}
+	return true
+}
+
+// text returns the input from a to b. When reading, input shares memory
+// with the window, which is reused, so the text is copied.
+func (l *Lexer) text(a, b Pos) string {
+	if l.buf != nil {
+		return string(l.buf[a:b])
+	}
+	return l.input[a:b]
+}
@@ -0,0 +1,113 @@
+package tokens
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewReaderLexer(t *testing.T) {
+	long := rfc1215 + `
+bigObject OBJECT-TYPE
+	DESCRIPTION "` + strings.Repeat("a very long description\r\n", 400) + `"
+	::= { big 1 } -- trailing comment`
+	tests := []struct {
+		name  string
+		input string
+		r     func(io.Reader) io.Reader
+	}{
+		{name: "rfc1215", input: rfc1215, r: func(r io.Reader) io.Reader { return r }},
+		{name: "one byte reads", input: rfc1215, r: iotest.OneByteReader},
+		{name: "long string", input: long, r: iotest.HalfReader},
+		{name: "long string one byte reads", input: long, r: iotest.OneByteReader},
+		{name: "unterminated", input: `a "b`, r: iotest.OneByteReader},
+	}
+	for _, tt := range tests {
+		for _, opts := range [][]Option{nil, {KeepTrivia()}} {
+			t.Run(tt.name, func(t *testing.T) {
+				want := NewLexer(tt.input, opts...)
+				got := NewReaderLexer(tt.r(strings.NewReader(tt.input)), opts...)
+				var wants, gots []Token
+				for {
+					w, g := want.NextToken(), got.NextToken()
+					if g != w {
+						t.Fatalf("NextToken() = %+v, want %+v", g, w)
+					}
+					wants, gots = append(wants, w), append(gots, g)
+					if w.Typ == EOF || w.Typ == Error {
+						break
+					}
+				}
+				// The values must not change as the window is reused.
+				if !reflect.DeepEqual(gots, wants) {
+					t.Errorf("tokens changed after scanning")
+				}
+			})
+		}
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestNewReaderLexer_readError(t *testing.T) {
+	readErr := errors.New("read failed")
+	lexer := NewReaderLexer(io.MultiReader(strings.NewReader("a b"), errReader{readErr}))
+	var got []TokenType
+	for {
+		tk := lexer.NextToken()
+		got = append(got, tk.Typ)
+		if tk.Typ == EOF || tk.Typ == Error {
+			break
+		}
+	}
+	if want := []TokenType{ValueReference, ValueReference, Error}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tokens = %v, want %v", got, want)
+	}
+	if err := lexer.Err(); err != readErr {
+		t.Errorf("Err() = %v, want %v", err, readErr)
+	}
+}
+
+func Benchmark_BigMIBReader(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		f, err := os.Open("TIMETRA-SUBSCRIBER-MGMT-MIB")
+		if err != nil {
+			b.Fatal(err)
+		}
+		lexer := NewReaderLexer(f)
+		for {
+			token := lexer.NextToken()
+			if token.Typ == Error {
+				break
+			}
+			if token.Typ == EOF {
+				break
+			}
+		}
+		f.Close()
+	}
+}
+
+func Benchmark_LongStringReader(b *testing.B) {
+	input := `DESCRIPTION "` + strings.Repeat("a very long description\n", 1<<20/24) + `"`
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		lexer := NewReaderLexer(strings.NewReader(input))
+		for {
+			token := lexer.NextToken()
+			if token.Typ == Error {
+				b.Fatal(token.Val)
+			}
+			if token.Typ == EOF {
+				break
+			}
+		}
+	}
+}
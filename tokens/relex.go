@@ -0,0 +1,135 @@
+package tokens
+
+import "sort"
+
+// Checkpoint is a point between tokens from which scanning can resume.
+type Checkpoint struct {
+	Pos   Position // position of the next byte to scan
+	state stateFn
+}
+
+// Checkpoint returns the point the Lexer has reached. It is only
+// meaningful between calls to NextToken.
+func (l *Lexer) Checkpoint() Checkpoint {
+	return Checkpoint{Pos: l.startPos, state: l.state}
+}
+
+// Resume creates a scanner for the input string that continues from cp.
+// The input before cp must be the same as that of the Lexer cp was taken
+// from, and the options should be the same.
+func Resume(input string, cp Checkpoint, opts ...Option) *Lexer {
+	l := NewLexer(input, opts...)
+	l.pos, l.start = cp.Pos.Offset, cp.Pos.Offset
+	l.line = cp.Pos.Line
+	l.lineStart = cp.Pos.Offset - Pos(cp.Pos.Column-1)
+	l.startPos = cp.Pos
+	l.state = cp.state
+	return l
+}
+
+// Edit replaces the bytes [Start, End) of an input with Text.
+type Edit struct {
+	Start, End Pos
+	Text       string
+}
+
+// Change is the effect of an Edit on the tokens of an input: the old
+// tokens [Start, End) are replaced by Tokens, and the tokens from End on
+// are unchanged apart from their positions.
+type Change struct {
+	Start, End int
+	Tokens     []Token
+
+	offset Pos // shift of the offsets of the tokens from End
+	lines  int // shift of the line numbers of the tokens from End
+	line   int // old line of the token at End
+	cols   int // shift of the columns on line
+}
+
+// Apply returns the tokens of the edited input given the old tokens.
+func (c Change) Apply(old []Token) []Token {
+	toks := make([]Token, 0, len(old)-(c.End-c.Start)+len(c.Tokens))
+	toks = append(toks, old[:c.Start]...)
+	toks = append(toks, c.Tokens...)
+	for _, tk := range old[c.End:] {
+		tk.Start, tk.End = c.shift(tk.Start), c.shift(tk.End)
+		toks = append(toks, tk)
+	}
+	return toks
+}
+
+// shift moves an old position after the edit to its new position.
+func (c Change) shift(p Position) Position {
+	if p.Line == c.line {
+		p.Column += c.cols
+	}
+	p.Offset += c.offset
+	p.Line += c.lines
+	return p
+}
+
+// Relex rescans only the part of input affected by edit. The old tokens
+// are those of the input before the edit, through EOF, and the options
+// must be those used to scan them. Scanning resumes after the last token
+// that cannot have been changed by the edit and stops once the tokens
+// match the old tokens again.
+func Relex(input string, old []Token, edit Edit, opts ...Option) Change {
+	// The lexer looks up to two bytes past the trivia after a token, so
+	// the tokens ending before that are unchanged.
+	start := sort.Search(len(old), func(i int) bool {
+		return old[i].End.Offset+Pos(len(old[i].Trailing))+1 >= edit.Start
+	})
+	var l *Lexer
+	if start == 0 {
+		l = NewLexer(input, opts...)
+	} else {
+		prev := old[start-1]
+		n := len(prev.Trailing)
+		l = Resume(input, Checkpoint{
+			Pos: Position{
+				Offset: prev.End.Offset + Pos(n),
+				Line:   prev.End.Line,
+				Column: prev.End.Column + n,
+			},
+			state: lexSpace,
+		}, opts...)
+	}
+
+	c := Change{
+		Start:  start,
+		End:    len(old),
+		offset: Pos(len(edit.Text)) - (edit.End - edit.Start),
+	}
+	j := start // first old token that may match
+	for {
+		tk := l.NextToken()
+		if tk.Typ == None {
+			break
+		}
+		for j < len(old) && (old[j].Start.Offset < edit.End || old[j].Start.Offset+c.offset < tk.Start.Offset) {
+			j++
+		}
+		if j < len(old) && sameToken(tk, old[j], c.offset) {
+			c.End = j
+			c.lines = tk.Start.Line - old[j].Start.Line
+			c.line = old[j].Start.Line
+			c.cols = tk.Start.Column - old[j].Start.Column
+			break
+		}
+		c.Tokens = append(c.Tokens, tk)
+		if tk.Typ == EOF || tk.Typ == Error {
+			break
+		}
+	}
+	return c
+}
+
+// sameToken reports if tk is the old token moved by offset.
+func sameToken(tk, old Token, offset Pos) bool {
+	return tk.Typ == old.Typ &&
+		tk.Val == old.Val &&
+		tk.Leading == old.Leading &&
+		tk.Trailing == old.Trailing &&
+		tk.Start.Offset == old.Start.Offset+offset &&
+		tk.End.Offset == old.End.Offset+offset
+}
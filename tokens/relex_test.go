@@ -0,0 +1,74 @@
+package tokens
+
+import (
+	"reflect"
+	"testing"
+)
+
+const relexInput = `A-MIB DEFINITIONS ::= BEGIN -- a module
+ifIndex OBJECT-TYPE
+    SYNTAX  INTEGER (1..2147483647) -- range
+    DESCRIPTION
+            "A unique value,
+            greater than zero."
+    ::= { ifEntry 1 }
+x OCTET STRING ::= '0F'H
+END
+`
+
+func lexAll(input string, opts ...Option) []Token {
+	var toks []Token
+	l := NewLexer(input, opts...)
+	for {
+		tk := l.NextToken()
+		toks = append(toks, tk)
+		if tk.Typ == EOF || tk.Typ == Error {
+			return toks
+		}
+	}
+}
+
+func TestRelex(t *testing.T) {
+	edits := []string{"", "x", "-", "--", "\"", "\n", " ", "'", "9", "::="}
+	for _, opts := range [][]Option{nil, {KeepTrivia()}} {
+		old := lexAll(relexInput, opts...)
+		for start := 0; start <= len(relexInput); start++ {
+			for _, n := range []int{0, 1, 3} {
+				end := start + n
+				if end > len(relexInput) {
+					continue
+				}
+				for _, text := range edits {
+					input := relexInput[:start] + text + relexInput[end:]
+					e := Edit{Start: Pos(start), End: Pos(end), Text: text}
+					c := Relex(input, old, e, opts...)
+					got, want := c.Apply(old), lexAll(input, opts...)
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("Relex(%+v, trivia %v) = %v, want %v", e, opts != nil, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRelex_span(t *testing.T) {
+	old := lexAll(relexInput)
+	start := Pos(len("A-MIB DEFINITIONS ::= BEGIN -- a module\nif"))
+	c := Relex(relexInput[:start]+"X"+relexInput[start:], old, Edit{Start: start, End: start, Text: "X"})
+	if c.Start != 4 || c.End != 5 || len(c.Tokens) != 1 || c.Tokens[0].Val != "ifXIndex" {
+		t.Errorf("Relex() = %d..%d %v, want 4..5 [ifXIndex]", c.Start, c.End, c.Tokens)
+	}
+}
+
+func TestResume(t *testing.T) {
+	l := NewLexer(relexInput)
+	for i := 0; i < 5; i++ {
+		l.NextToken()
+	}
+	cp := l.Checkpoint()
+	want := l.NextToken()
+	if got := Resume(relexInput, cp).NextToken(); got != want {
+		t.Errorf("Resume().NextToken() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package tokens
+
+import "fmt"
+
+// Stream reads tokens from a Lexer with arbitrary lookahead and
+// backtracking for recursive descent parsers. After EOF or an Error token
+// the Stream keeps returning that token.
+type Stream struct {
+	lex   *Lexer
+	buf   []Token // tokens read but not yet discarded
+	i     int     // index in buf of the next token
+	off   int     // number of tokens discarded from the front of buf
+	marks []int   // marks not yet reset or released, oldest first
+	last  Token   // last token read from lex
+}
+
+// NewStream creates a Stream reading from l.
+func NewStream(l *Lexer) *Stream {
+	return &Stream{lex: l}
+}
+
+// fill reads tokens until buf has n tokens after i.
+func (s *Stream) fill(n int) {
+	for len(s.buf)-s.i < n {
+		if s.last.Typ != EOF && s.last.Typ != Error {
+			s.last = s.lex.NextToken()
+		}
+		s.buf = append(s.buf, s.last)
+	}
+}
+
+// Peek returns the token n tokens ahead without consuming it; Peek(0)
+// is the token Next returns.
+func (s *Stream) Peek(n int) Token {
+	s.fill(n + 1)
+	return s.buf[s.i+n]
+}
+
+// Next consumes and returns the next token.
+func (s *Stream) Next() Token {
+	tk := s.Peek(0)
+	s.i++
+	if len(s.marks) == 0 && s.i > len(s.buf)/2 { // discard the consumed tokens
+		n := copy(s.buf, s.buf[s.i:])
+		s.buf = s.buf[:n]
+		s.off += s.i
+		s.i = 0
+	}
+	return tk
+}
+
+// Mark returns the current position in the stream so that Reset can return
+// to it. Tokens are retained from the oldest mark, so every mark must be
+// passed to either Reset or Release, in the reverse of the order in which
+// the marks were made.
+func (s *Stream) Mark() int {
+	m := s.off + s.i
+	s.marks = append(s.marks, m)
+	return m
+}
+
+// Reset returns the stream to mark m so that the tokens since are read
+// again. It panics if m is not the most recent outstanding mark.
+func (s *Stream) Reset(m int) {
+	s.pop(m)
+	s.i = m - s.off
+}
+
+// Release discards mark m, keeping the tokens read since. It panics if m
+// is not the most recent outstanding mark.
+func (s *Stream) Release(m int) {
+	s.pop(m)
+}
+
+// pop removes m from the top of the marks.
+func (s *Stream) pop(m int) {
+	n := len(s.marks)
+	if n == 0 || s.marks[n-1] != m {
+		panic(fmt.Sprintf("tokens: mark %d is not the most recent outstanding mark", m))
+	}
+	s.marks = s.marks[:n-1]
+}
+
+// Expect consumes the next token if it is of type typ. Otherwise it
+// returns an UnexpectedToken *SyntaxError, or the lexer's error for an Error
+// token, and the token is not consumed.
+func (s *Stream) Expect(typ TokenType) (Token, error) {
+	tk := s.Peek(0)
+	switch {
+	case tk.Typ == typ:
+		return s.Next(), nil
+	case tk.Typ == Error && s.lex.Err() != nil:
+		return tk, s.lex.Err()
+	}
+	return tk, &SyntaxError{
+		Kind: UnexpectedToken,
+		Pos:  tk.Start,
+		Msg:  fmt.Sprintf("expected %v, found %s", typ, tk),
+	}
+}
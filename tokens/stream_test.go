@@ -0,0 +1,105 @@
+package tokens
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	s := NewStream(NewLexer("a OBJECT IDENTIFIER ::= { b 1 }"))
+	if got := s.Peek(2).Typ; got != Identifier {
+		t.Fatalf("Peek(2) = %v, want Identifier", got)
+	}
+	if got := s.Peek(0).Val; got != "a" {
+		t.Fatalf("Peek(0) = %q, want a", got)
+	}
+	if got := s.Next().Val; got != "a" {
+		t.Fatalf("Next() = %q, want a", got)
+	}
+
+	m := s.Mark()
+	for s.Next().Typ != LeftBracket {
+	}
+	inner := s.Mark()
+	s.Next()
+	s.Reset(inner)
+	if got := s.Next().Val; got != "b" {
+		t.Errorf("Next() after inner Reset = %q, want b", got)
+	}
+	s.Reset(m)
+	if got := s.Next().Typ; got != Object {
+		t.Errorf("Next() after Reset = %v, want Object", got)
+	}
+
+	m = s.Mark()
+	s.Next()
+	s.Release(m)
+	if _, err := s.Expect(Equals); err != nil {
+		t.Errorf("Expect(Equals) error = %v", err)
+	}
+	tk, err := s.Expect(RightBracket)
+	var serr *SyntaxError
+	if !errors.As(err, &serr) || serr.Kind != UnexpectedToken || tk.Typ != LeftBracket {
+		t.Errorf("Expect(RightBracket) = %v, %v, want UnexpectedToken", tk, err)
+	}
+	if got := s.Next().Typ; got != LeftBracket {
+		t.Errorf("Next() after failed Expect = %v, want LeftBracket", got)
+	}
+	for i := 0; i < 3; i++ {
+		s.Next()
+	}
+	for i := 0; i < 2; i++ {
+		if got := s.Next().Typ; got != EOF {
+			t.Errorf("Next() at end = %v, want EOF", got)
+		}
+	}
+}
+
+func TestStream_lexerError(t *testing.T) {
+	s := NewStream(NewLexer(`a "b`))
+	s.Next()
+	_, err := s.Expect(Quotestring)
+	var serr *SyntaxError
+	if !errors.As(err, &serr) || serr.Kind != UnterminatedString {
+		t.Errorf("Expect() error = %v, want unterminated string", err)
+	}
+	if got := s.Peek(3).Typ; got != Error {
+		t.Errorf("Peek(3) = %v, want Error", got)
+	}
+}
+
+func TestStream_marks(t *testing.T) {
+	mustPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+
+	s := NewStream(NewLexer("a b c d e f g h"))
+	m := s.Mark()
+	s.Next()
+	s.Reset(m)
+	mustPanic("second Reset", func() { s.Reset(m) })
+	mustPanic("Release after Reset", func() { s.Release(m) })
+
+	m = s.Mark()
+	s.Release(m)
+	mustPanic("second Release", func() { s.Release(m) })
+
+	outer := s.Mark()
+	s.Next()
+	inner := s.Mark()
+	mustPanic("Release out of order", func() { s.Release(outer) })
+	s.Release(inner)
+	s.Reset(outer)
+
+	for s.Next().Typ != EOF {
+	}
+	if len(s.buf) > 2 {
+		t.Errorf("len(buf) = %d after all marks are released, want the buffer compacted", len(s.buf))
+	}
+}
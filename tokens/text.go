@@ -0,0 +1,69 @@
+package tokens
+
+import "strings"
+
+// Text returns the contents of a Quotestring token without its quotes.
+// Other tokens return Val.
+func (t Token) Text() string {
+	if t.Typ == Quotestring && len(t.Val) >= 2 {
+		return t.Val[1 : len(t.Val)-1]
+	}
+	return t.Val
+}
+
+// Dedent removes the indentation that the lines of a DESCRIPTION,
+// REFERENCE or CONTACT-INFO string have in common, ignoring the first line
+// which follows the opening quote. Line endings become "\n", trailing
+// spaces are removed, and leading and trailing blank lines are dropped.
+func Dedent(s string) string {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	s = strings.Replace(s, "\r", "\n", -1)
+	lines := strings.Split(s, "\n")
+	lines[0] = strings.TrimLeft(lines[0], " \t")
+
+	indent, found := "", false
+	for _, line := range lines[1:] {
+		text := strings.TrimLeft(line, " \t")
+		if text == "" {
+			continue
+		}
+		ws := line[:len(line)-len(text)]
+		if !found {
+			indent, found = ws, true
+			continue
+		}
+		for !strings.HasPrefix(ws, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	for i := range lines {
+		lines[i] = strings.TrimRight(strings.TrimPrefix(lines[i], indent), " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// NormalizeText dedents s and joins hard-wrapped lines with a space so
+// each paragraph is a single line. Paragraphs are separated by a blank
+// line. Lines that are still indented after dedenting, such as lists and
+// addresses, are kept as they are.
+func NormalizeText(s string) string {
+	var (
+		out  []string // output lines; "" separates paragraphs
+		join bool     // if the next line continues the last output line
+	)
+	for _, line := range strings.Split(Dedent(s), "\n") {
+		indented := line != "" && (line[0] == ' ' || line[0] == '\t')
+		switch {
+		case line == "":
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+		case join && !indented:
+			out[len(out)-1] += " " + line
+		default:
+			out = append(out, line)
+		}
+		join = line != "" && !indented
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,115 @@
+package tokens
+
+import "testing"
+
+func TestToken_Text(t *testing.T) {
+	tests := []struct {
+		name string
+		tk   Token
+		want string
+	}{
+		{name: "string", tk: Token{Typ: Quotestring, Val: `"a b"`}, want: "a b"},
+		{name: "empty string", tk: Token{Typ: Quotestring, Val: `""`}, want: ""},
+		{name: "multiline string", tk: Token{Typ: Quotestring, Val: "\"a\r\n  b\""}, want: "a\r\n  b"},
+		{name: "label", tk: Token{Typ: ValueReference, Val: "ifIndex"}, want: "ifIndex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tk.Text(); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+const ifDescr = `A textual string containing information about the
+            interface.  This string should include the name of the
+            manufacturer, the product name and the version of the
+            interface hardware/software.
+
+            Values are:
+              1 - up
+              2 - down
+
+            `
+
+func TestDedent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "description",
+			input: ifDescr,
+			want: "A textual string containing information about the\n" +
+				"interface.  This string should include the name of the\n" +
+				"manufacturer, the product name and the version of the\n" +
+				"interface hardware/software.\n" +
+				"\n" +
+				"Values are:\n" +
+				"  1 - up\n" +
+				"  2 - down",
+		},
+		{
+			name:  "starts on next line",
+			input: "\r\n\t\tfirst\r\n\t\t  second\r\n\t",
+			want:  "first\n  second",
+		},
+		{
+			name:  "single line",
+			input: "The index.",
+			want:  "The index.",
+		},
+		{
+			name:  "mixed indentation",
+			input: "a\n\t  b\n\t c",
+			want:  "a\n b\nc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dedent(tt.input); got != tt.want {
+				t.Errorf("Dedent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "description",
+			input: ifDescr,
+			want: "A textual string containing information about the " +
+				"interface.  This string should include the name of the " +
+				"manufacturer, the product name and the version of the " +
+				"interface hardware/software.\n" +
+				"\n" +
+				"Values are:\n" +
+				"  1 - up\n" +
+				"  2 - down",
+		},
+		{
+			name:  "many blank lines",
+			input: "one\r\n   two\r\n\r\n\r\n   three",
+			want:  "one two\n\nthree",
+		},
+		{
+			name:  "empty",
+			input: "  \n  ",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeText(tt.input); got != tt.want {
+				t.Errorf("NormalizeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,16 +2,27 @@ package tokens
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
+//go:generate stringer -type=TokenType
+
 // TokenType is one of the specific MIB token types.
 type TokenType uint
 
 // Token is a string in a MIB file with an identified meaning.
 type Token struct {
-	Typ TokenType
-	Val string
+	Typ   TokenType
+	Val   string
+	Start Position // position of the first byte of the token
+	End   Position // position just past the last byte of the token
+
+	// Leading and Trailing are the spaces and comments before and after
+	// the token; they are only kept when lexing with KeepTrivia. Trailing
+	// trivia ends before the next newline.
+	Leading  string
+	Trailing string
 }
 
 func (t Token) String() string {
@@ -44,6 +55,10 @@ const (
 	Range
 	Label
 	Equals
+	Number
+	NegativeNumber
+	TypeReference
+	ValueReference
 	EOF
 	Keyword
 	Obsolete
@@ -137,6 +152,22 @@ const (
 	Notifname
 	Variables
 	Quotestring
+	PibDefinitions
+	PibAccess
+	PibReferences
+	PibTag
+	PibIndex
+	PibMinAccess
+	Extends
+	Uniqueness
+	InstallErrors
+	SubjectCategories
+	Integer64
+	Unsigned64
+	Install
+	Notify
+	InstallNotify
+	ReportOnly
 )
 
 var lexemes = map[string]TokenType{
@@ -248,32 +279,69 @@ const (
 	ffASCII    = byte(0x0D)
 )
 
+// maxIdentifier is the longest identifier allowed by RFC 2578.
+const maxIdentifier = 64
+
 // Pos represents a byte position in the original input text from which
 // this token was parsed.
 type Pos int
 
+// Position is a location in the input; lines and columns start at 1 and
+// columns count bytes.
+type Position struct {
+	Offset Pos
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
 // stateFn represents the state of the scanner as a function that returns the next state.
 type stateFn func(*Lexer) (stateFn, Token)
 
 // Lexer holds the state of the scanner.
 type Lexer struct {
-	input string   // string to scan
-	state stateFn  // the next lexing function to enter
-	pos   Pos      // current position in the input
-	start Pos      // start position of this item
-	width Pos      // width of last []byte read from input
-	label [21]byte // buffer used to compare keywords
+	input     string               // string to scan; a window of the input when reading
+	base      Pos                  // offset of input[0] in the whole input
+	state     stateFn              // the next lexing function to enter
+	pos       Pos                  // current position in the input
+	start     Pos                  // start position of this item
+	width     Pos                  // width of last []byte read from input
+	label     [21]byte             // buffer used to compare keywords
+	line      int                  // line number of pos
+	lineStart Pos                  // offset in the whole input of the first byte of the line of pos
+	prevLine  Pos                  // lineStart of the previous line; used by backup
+	startPos  Position             // position of start
+	err       *SyntaxError         // error that stopped the scan
+	diags     []*SyntaxError       // all errors found
+	recover   bool                 // skip bad input rather than stopping
+	strict    bool                 // report input the C parser accepts
+	lenient   bool                 // record warnings for vendor mistakes
+	warnings  []*SyntaxError       // mistakes accepted
+	trivia    bool                 // keep spaces and comments on tokens
+	leading   string               // trivia skipped since the last token
+	enc       Encoding             // encoding of quoted strings
+	keywords  map[string]TokenType // keyword table of the dialect
+	r         io.Reader            // source of more input; nil once exhausted
+	buf       []byte               // window of input read from r; input is a view of it
+	rerr      error                // error reading from r
 }
 
 // next returns the next byte in the input.
 func (l *Lexer) next() byte {
-	if int(l.pos) >= len(l.input) {
+	if int(l.pos) >= len(l.input) && !l.fill(1) {
 		l.width = 0
 		return eof
 	}
 	r := l.input[l.pos]
 	l.width = 1
 	l.pos += l.width
+	if r == '\n' {
+		l.line++
+		l.prevLine, l.lineStart = l.lineStart, l.base+l.pos
+	}
 	return r
 }
 
@@ -287,27 +355,108 @@ func (l *Lexer) peek() byte {
 // backup steps back one []byte. Can only be called once per call of next.
 func (l *Lexer) backup() {
 	l.pos -= l.width
+	if l.width == 1 && l.input[l.pos] == '\n' {
+		l.line--
+		l.lineStart = l.prevLine
+	}
+}
+
+// position returns the Position of pos.
+func (l *Lexer) position() Position {
+	return Position{
+		Offset: l.base + l.pos,
+		Line:   l.line,
+		Column: int(l.base+l.pos-l.lineStart) + 1,
+	}
 }
 
 // emit passes an item back to the client.
 func (l *Lexer) emit(t TokenType) Token {
+	val := l.text(l.start, l.pos)
+	if t == Quotestring {
+		val = l.enc.decode(val)
+	}
 	tk := Token{
-		Typ: t,
-		Val: l.input[l.start:l.pos],
+		Typ:     t,
+		Val:     val,
+		Start:   l.startPos,
+		End:     l.position(),
+		Leading: l.leading,
 	}
 	l.start = l.pos
+	l.startPos = tk.End
+	l.leading = ""
 	return tk
 }
 
 // ignore skips over the pending input before this point.
 func (l *Lexer) ignore() {
+	if l.trivia && l.pos > l.start {
+		l.leading += l.text(l.start, l.pos)
+	}
 	l.start = l.pos
+	l.startPos = l.position()
+}
+
+// errorf records a SyntaxError of kind caused by byte c. It returns an
+// error token and terminates the scan by passing back a nil pointer that
+// will be the next state, terminating l.nextItem. The error token spans from
+// the start of the pending item to pos.
+//
+// When recovering, the pending input is skipped and the scan continues.
+func (l *Lexer) errorf(kind ErrorKind, c byte, format string, args ...interface{}) (stateFn, Token) {
+	err := &SyntaxError{
+		Kind: kind,
+		Char: c,
+		Pos:  l.startPos,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+	l.diags = append(l.diags, err)
+	if l.recover {
+		l.ignore()
+		return lexSpace, Token{}
+	}
+	l.err = err
+	return nil, Token{
+		Typ:   Error,
+		Val:   err.Msg,
+		Start: l.startPos,
+		End:   l.position(),
+	}
+}
+
+// warnf records a problem with the pending item as a warning.
+func (l *Lexer) warnf(kind ErrorKind, c byte, format string, args ...interface{}) {
+	l.warnings = append(l.warnings, &SyntaxError{
+		Kind: kind,
+		Char: c,
+		Pos:  l.startPos,
+		Msg:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Err returns the *SyntaxError or read error that stopped the scan, if any.
+// A recovering Lexer only stops on read errors; see Diagnostics.
+func (l *Lexer) Err() error {
+	switch {
+	case l.err != nil:
+		return l.err
+	case l.rerr != nil:
+		return l.rerr
+	}
+	return nil
 }
 
-// errorf returns an error token and terminates the scan by passing
-// back a nil pointer that will be the next state, terminating l.nextItem.
-func (l *Lexer) errorf(format string, args ...interface{}) Token {
-	return Token{Error, fmt.Sprintf(format, args...)}
+// Diagnostics returns every SyntaxError found so far.
+func (l *Lexer) Diagnostics() []*SyntaxError {
+	return l.diags
+}
+
+// Warnings returns the problems found so far that did not stop the scan:
+// the identifier mistakes accepted in Lenient mode and, in Strict or
+// Lenient mode, quoted strings containing `--`.
+func (l *Lexer) Warnings() []*SyntaxError {
+	return l.warnings
 }
 
 // NextToken returns the next token from the input.
@@ -315,7 +464,14 @@ func (l *Lexer) NextToken() Token {
 	var tk Token
 	for l.state != nil {
 		l.state, tk = l.state(l)
+		if tk.Typ == EOF && l.rerr != nil {
+			tk.Typ, tk.Val = Error, l.rerr.Error()
+			l.state = nil
+		}
 		if tk.Typ != None {
+			if l.trivia && l.state != nil {
+				tk.Trailing = l.trailing()
+			}
 			return tk
 		}
 	}
@@ -323,11 +479,18 @@ func (l *Lexer) NextToken() Token {
 }
 
 // NewLexer creates a new scanner for the input string.
-func NewLexer(input string) *Lexer {
-	return &Lexer{
-		input: input,
-		state: lexSpace,
+func NewLexer(input string, opts ...Option) *Lexer {
+	l := &Lexer{
+		input:    input,
+		state:    lexBOM,
+		keywords: lexemes,
+		line:     1,
+		startPos: Position{Line: 1, Column: 1},
+	}
+	for _, opt := range opts {
+		opt(l)
 	}
+	return l
 }
 
 const (
@@ -336,7 +499,8 @@ const (
 )
 
 func lexText(l *Lexer) (stateFn, Token) {
-	if strings.HasPrefix(l.input[l.pos:], "--") {
+	l.fill(len(comment))
+	if strings.HasPrefix(l.input[l.pos:], comment) {
 		l.ignore()
 		return lexComment, Token{}
 	}
@@ -373,12 +537,26 @@ func lexText(l *Lexer) (stateFn, Token) {
 	case r <= maxASCII && r >= spaceASCII:
 		return lexChars, Token{}
 	default:
-		return nil, l.errorf("unrecognized character: %#U", r)
+		if l.recover { // report a run of bad bytes, such as UTF-8, once
+			for invalid(l.peek()) {
+				l.next()
+			}
+		}
+		return l.errorf(InvalidCharacter, r, "unrecognized character: %#U", r)
 	}
 
 	return nil, l.emit(EOF)
 }
 
+// invalid reports if r cannot begin a token or be skipped as space.
+func invalid(r byte) bool {
+	switch r {
+	case eof, htASCII, lfASCII, vtASCII, ffASCII, crASCII, 0x85, 0xA0:
+		return false
+	}
+	return r > maxASCII || r < spaceASCII
+}
+
 func lexQuotedString(l *Lexer) (stateFn, Token) {
 Loop:
 	for {
@@ -386,14 +564,21 @@ Loop:
 		case '\r', '\n':
 			continue
 		case eof:
-			return nil, l.errorf("unterminated quoted string")
+			return l.errorf(UnterminatedString, eof, "unterminated quoted string")
 		case '"':
 			break Loop
 		}
 	}
+	if (l.strict || l.lenient) && strings.Contains(l.input[l.start:l.pos], "--") {
+		l.warnf(CommentInString, '-', "quoted string contains --")
+	}
 	return lexSpace, l.emit(Quotestring)
 }
 
+// lexNumberLiteral scans a 'B or 'H literal; the opening quote is known
+// to be present. Like the C parser it is lenient by default: 'B accepts any
+// digits, and a literal with an unknown suffix is a label. Strict lexers
+// report those as errors instead.
 func lexNumberLiteral(l *Lexer) (stateFn, Token) {
 	const (
 		binary uint = 1 << iota
@@ -401,6 +586,7 @@ func lexNumberLiteral(l *Lexer) (stateFn, Token) {
 		unknown
 	)
 	numType := binary
+	var notBinary, notHex byte // first digit that is not binary or not hex
 
 Loop:
 	for {
@@ -409,21 +595,49 @@ Loop:
 			numType |= binary
 		case ('0' <= r && r <= '9') || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F'):
 			numType |= hex
+			if notBinary == 0 {
+				notBinary = r
+			}
 		case r == eof:
-			return nil, l.errorf("unterminated literal string")
+			return l.errorf(UnterminatedLiteral, eof, "unterminated literal string")
 		case r == '\'':
 			break Loop
 		default:
 			numType |= unknown
+			if notBinary == 0 {
+				notBinary = r
+			}
+			if notHex == 0 {
+				notHex = r
+			}
 		}
 	}
 
-	r := l.next()
-	if r >= aASCII && r <= zASCII { // TODO(goller): comment as toupper
+	c := l.next()
+	r := c
+	if r >= aASCII && r <= zASCII { // to upper case
 		r -= 0x20
 	}
+	if l.strict {
+		switch {
+		case r == 'B' && numType&(hex|unknown) == 0:
+			return lexSpace, l.emit(Binary)
+		case r == 'H' && numType&unknown == 0:
+			return lexSpace, l.emit(Hex)
+		case r == 'B' && numType&unknown == 0:
+			return l.errorf(HexDigitInBinary, notBinary, "hex digit %q in binary string", notBinary)
+		case r == 'B': // report the byte that is not even a hex digit
+			return l.errorf(InvalidLiteralDigit, notHex, "invalid digit %q in binary string", notHex)
+		case r == 'H':
+			return l.errorf(InvalidLiteralDigit, notHex, "invalid digit %q in hex string", notHex)
+		case r >= 'A' && r <= 'Z':
+			return l.errorf(UnknownRadix, c, "unknown radix %q in literal string; want B or H", c)
+		}
+		l.backup()
+		return l.errorf(MissingRadix, c, "literal string is missing B or H suffix")
+	}
 	switch {
-	case r == 'B' && numType&binary == binary: // TODO(goller): shoudn't this only be binary?
+	case r == 'B' && numType&binary == binary:
 		return lexSpace, l.emit(Binary)
 	case r == 'H' && numType&unknown == 0:
 		return lexSpace, l.emit(Hex)
@@ -440,6 +654,12 @@ LOOP:
 		switch r := l.peek(); r {
 		case htASCII, lfASCII, vtASCII, ffASCII, crASCII, spaceASCII, 0x85, 0xA0:
 			_ = l.next()
+		case 0xC2: // UTF-8 encoded NEL or no-break space
+			n := l.unicodeSpace()
+			if n == 0 {
+				break LOOP
+			}
+			l.pos += n
 		default:
 			break LOOP
 
@@ -481,6 +701,7 @@ func lexComment(l *Lexer) (stateFn, Token) {
 	for {
 		switch r := l.next(); {
 		case r == eof:
+			l.ignore()
 			return nil, l.emit(EOF)
 		case r == '\n':
 			l.ignore()
@@ -494,9 +715,49 @@ func lexComment(l *Lexer) (stateFn, Token) {
 	}
 }
 
+// trailing skips the spaces and comments after a token up to the end of
+// the line and returns them.
+func (l *Lexer) trailing() string {
+Loop:
+	for {
+		switch r := l.next(); r {
+		case htASCII, vtASCII, 0x0C, spaceASCII, 0x85, 0xA0:
+		case dashASCII:
+			l.backup()
+			l.fill(len(comment))
+			if !strings.HasPrefix(l.input[l.pos:], comment) {
+				break Loop
+			}
+			l.pos += Pos(len(comment))
+			var prev byte
+		Comment:
+			for {
+				switch r := l.next(); {
+				case r == eof:
+					break Loop
+				case r == '\r', r == '\n':
+					l.backup()
+					break Loop
+				case prev == dashASCII && r == dashASCII:
+					break Comment
+				default:
+					prev = r
+				}
+			}
+		default:
+			l.backup()
+			break Loop
+		}
+	}
+	trailing := l.text(l.start, l.pos)
+	l.start = l.pos
+	l.startPos = l.position()
+	return trailing
+}
+
 // lexChars accumulate characters until end of token is found.
 // If the token is a reserved word return the type otherwise,
-// assume a label.
+// assume a number, reference or label.
 func lexChars(l *Lexer) (stateFn, Token) {
 	n := 0
 	l.label[n] = l.input[l.start]
@@ -518,7 +779,8 @@ func lexChars(l *Lexer) (stateFn, Token) {
 		l.label[n] = r
 		n++
 	default:
-		return lexSpace, l.emit(Label)
+		l.backup()
+		return l.emitWord()
 	}
 
 LOOP:
@@ -550,10 +812,73 @@ LOOP:
 	}
 
 	if n != -1 {
-		if keyword, ok := lexemes[string(l.label[0:n])]; ok {
+		if keyword, ok := l.keywords[string(l.label[0:n])]; ok {
 			return lexSpace, l.emit(keyword)
 		}
 	}
 
-	return lexSpace, l.emit(Label)
+	return l.emitWord()
+}
+
+// emitWord emits the pending word, which is not a keyword, as a number,
+// a type reference when it begins with an uppercase letter, a value
+// reference when it begins with a lowercase letter or otherwise a label.
+// Strict and Lenient lexers check the word against the identifier rules.
+func (l *Lexer) emitWord() (stateFn, Token) {
+	word := l.input[l.start:l.pos]
+	typ := Label
+	switch {
+	case word[0] >= 'A' && word[0] <= 'Z':
+		typ = TypeReference
+	case word[0] >= 'a' && word[0] <= 'z':
+		typ = ValueReference
+	case isDigits(word):
+		typ = Number
+	case word[0] == '-' && isDigits(word[1:]):
+		typ = NegativeNumber
+	}
+	if (l.strict || l.lenient) && typ != Number && typ != NegativeNumber {
+		if kind, c, msg := checkIdentifier(word); kind != 0 {
+			if l.strict {
+				return l.errorf(kind, c, "%s", msg)
+			}
+			l.warnf(kind, c, "%s", msg)
+		}
+	}
+	return lexSpace, l.emit(typ)
+}
+
+// checkIdentifier returns the first way in which word breaks the
+// identifier rules of RFC 2578 section 3.1, the offending byte and a
+// message, or zero if it does not.
+func checkIdentifier(word string) (ErrorKind, byte, string) {
+	if c := word[0]; !(c >= 'A' && c <= 'Z') && !(c >= 'a' && c <= 'z') {
+		return InvalidIdentifier, c, fmt.Sprintf("invalid identifier %q; want a letter first", word)
+	}
+	if strings.Contains(word, "_") {
+		return UnderscoreInIdentifier, '_', fmt.Sprintf("underscore in identifier %q", word)
+	}
+	if strings.Contains(word, "--") {
+		return InvalidHyphen, '-', fmt.Sprintf("consecutive hyphens in identifier %q", word)
+	}
+	if word[len(word)-1] == '-' {
+		return InvalidHyphen, '-', fmt.Sprintf("identifier %q ends in a hyphen", word)
+	}
+	if len(word) > maxIdentifier {
+		return LongIdentifier, word[maxIdentifier], fmt.Sprintf("identifier %q is longer than %d characters", word, maxIdentifier)
+	}
+	return 0, 0, ""
+}
+
+// isDigits reports if s is a non-empty string of decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -3,6 +3,7 @@ package tokens
 import (
 	"io/ioutil"
 	"path/filepath"
+	"strconv"
 	"testing"
 )
 
@@ -47,7 +48,7 @@ func Test_lex(t *testing.T) {
 			name:  "full mib",
 			input: rfc1215,
 			want: []TokenType{
-				Label,
+				TypeReference,
 				Definitions,
 				Equals,
 				Begin,
@@ -55,69 +56,69 @@ func Test_lex(t *testing.T) {
 				Macro,
 				Equals,
 				Begin,
-				Label, // Type
-				Label,
+				TypeReference, // Type
+				TypeReference,
 				Equals,
 				Quotestring,
-				Label,
+				ValueReference,
 				LeftParen,
 				Enterprise,
 				Object,
 				Identifier,
 				RightParen,
-				Label,
-				Label,
-				Label,
-				Label, // Value
-				Label,
+				TypeReference,
+				TypeReference,
+				TypeReference,
+				TypeReference, // Value
+				TypeReference,
 				Equals,
-				Label,
+				ValueReference,
 				LeftParen,
-				Label,
+				TypeReference,
 				Integer,
 				RightParen,
-				Label, // VarPart
+				TypeReference, // VarPart
 				Equals,
 				Quotestring,
 				Quotestring,
-				Label,
+				TypeReference,
 				Quotestring,
 				Bar,
-				Label,
-				Label, // VarTypes
+				ValueReference,
+				TypeReference, // VarTypes
 				Equals,
-				Label,
+				TypeReference,
 				Bar,
-				Label,
+				TypeReference,
 				Quotestring,
-				Label,
-				Label, // VarType
+				TypeReference,
+				TypeReference, // VarType
 				Equals,
-				Label,
+				ValueReference,
 				LeftParen,
-				Label,
+				ValueReference,
 				Objname,
 				RightParen,
-				Label, // DescrPart
+				TypeReference, // DescrPart
 				Equals,
 				Quotestring,
-				Label,
+				ValueReference,
 				LeftParen,
 				Description,
-				Label,
+				TypeReference,
 				RightParen,
 				Bar,
-				Label,
-				Label, // ReferPart
+				ValueReference,
+				TypeReference, // ReferPart
 				Equals,
 				Quotestring,
-				Label,
+				ValueReference,
 				LeftParen,
 				Reference,
-				Label,
+				TypeReference,
 				RightParen,
 				Bar,
-				Label,
+				ValueReference,
 				End,
 				End,
 				EOF,
@@ -133,7 +134,27 @@ func Test_lex(t *testing.T) {
 		{
 			name:  "single letter",
 			input: `a`,
-			want:  []TokenType{Label, EOF},
+			want:  []TokenType{ValueReference, EOF},
+		},
+		{
+			name:  "single letter before bracket",
+			input: `{a}`,
+			want:  []TokenType{LeftBracket, ValueReference, RightBracket, EOF},
+		},
+		{
+			name:  "numbers",
+			input: `(0..4294967295 | -1)`,
+			want:  []TokenType{LeftParen, Number, Range, Number, Bar, NegativeNumber, RightParen, EOF},
+		},
+		{
+			name:  "type and value references",
+			input: `SYNTAX InterfaceIndex ::= { ifEntry 1 }`,
+			want:  []TokenType{Syntax, TypeReference, Equals, LeftBracket, ValueReference, Number, RightBracket, EOF},
+		},
+		{
+			name:  "number prefixed label",
+			input: `1a -1a -`,
+			want:  []TokenType{Label, Label, Label, EOF},
 		},
 		{
 			name:  "empty mib",
@@ -151,7 +172,7 @@ func Test_lex(t *testing.T) {
 			name: "inline comment eof",
 			input: `
 				-- comment -- howdy`,
-			want: []TokenType{Label, EOF},
+			want: []TokenType{ValueReference, EOF},
 		},
 		{
 			name:  "range",
@@ -161,7 +182,7 @@ func Test_lex(t *testing.T) {
 		{
 			name:  "period prefixed label",
 			input: `.label`,
-			want:  []TokenType{Label, Label, EOF},
+			want:  []TokenType{Label, ValueReference, EOF},
 		},
 		{
 			name:  "equals",
@@ -171,12 +192,12 @@ func Test_lex(t *testing.T) {
 		{
 			name:  "colon prefixed label",
 			input: `:label`,
-			want:  []TokenType{Label, Label, EOF},
+			want:  []TokenType{Label, ValueReference, EOF},
 		},
 		{
 			name:  "double colon prefixed label",
 			input: `::label`,
-			want:  []TokenType{Label, Label, EOF},
+			want:  []TokenType{Label, ValueReference, EOF},
 		},
 		{
 			name:  "hex number empty h",
@@ -340,6 +361,56 @@ func Test_lex(t *testing.T) {
 		})
 	}
 }
+
+func Test_positions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Token
+	}{
+		{
+			name:  "tokens across lines",
+			input: "a ::=\n  { b 1 }",
+			want: []Token{
+				{Typ: ValueReference, Val: "a", Start: Position{0, 1, 1}, End: Position{1, 1, 2}},
+				{Typ: Equals, Val: "::=", Start: Position{2, 1, 3}, End: Position{5, 1, 6}},
+				{Typ: LeftBracket, Val: "{", Start: Position{8, 2, 3}, End: Position{9, 2, 4}},
+				{Typ: ValueReference, Val: "b", Start: Position{10, 2, 5}, End: Position{11, 2, 6}},
+				{Typ: Number, Val: "1", Start: Position{12, 2, 7}, End: Position{13, 2, 8}},
+				{Typ: RightBracket, Val: "}", Start: Position{14, 2, 9}, End: Position{15, 2, 10}},
+				{Typ: EOF, Start: Position{15, 2, 10}, End: Position{15, 2, 10}},
+			},
+		},
+		{
+			name:  "multiline string",
+			input: "-- c\n\"x\ny\" z",
+			want: []Token{
+				{Typ: Quotestring, Val: "\"x\ny\"", Start: Position{5, 2, 1}, End: Position{10, 3, 3}},
+				{Typ: ValueReference, Val: "z", Start: Position{11, 3, 4}, End: Position{12, 3, 5}},
+				{Typ: EOF, Start: Position{12, 3, 5}, End: Position{12, 3, 5}},
+			},
+		},
+		{
+			name:  "unterminated string error starts at quote",
+			input: "a\n  \"abc\n",
+			want: []Token{
+				{Typ: ValueReference, Val: "a", Start: Position{0, 1, 1}, End: Position{1, 1, 2}},
+				{Typ: Error, Val: "unterminated quoted string", Start: Position{4, 2, 3}, End: Position{9, 3, 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			for i := range tt.want {
+				if got := lexer.NextToken(); got != tt.want[i] {
+					t.Errorf("token %d = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func Test_SNMP(t *testing.T) {
 	files, err := ioutil.ReadDir("/usr/share/snmp/mibs")
 	if err != nil {
@@ -466,16 +537,28 @@ func Benchmark_lex(b *testing.B) {
 func Benchmark_Dir(b *testing.B) {
 	files, _ := ioutil.ReadDir("/usr/share/snmp/mibs")
 
-	mibs := []string{}
+	mibs := [][]byte{}
+	strs := []string{}
 	for _, file := range files {
 		name := filepath.Join("/usr/share/snmp/mibs", file.Name())
 		buf, _ := ioutil.ReadFile(name)
-		mibs = append(mibs, string(buf))
+		mibs = append(mibs, buf)
+		strs = append(strs, string(buf))
 	}
-	b.ResetTimer()
+	b.Run("string", func(b *testing.B) {
+		benchmarkLex(b, len(strs), func(j int) *Lexer { return NewLexer(strs[j]) })
+	})
+	b.Run("bytes", func(b *testing.B) {
+		benchmarkLex(b, len(mibs), func(j int) *Lexer { return NewBytesLexer(mibs[j]) })
+	})
+}
+
+// benchmarkLex lexes each of n inputs made by newLexer to the end.
+func benchmarkLex(b *testing.B, n int, newLexer func(int) *Lexer) {
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		for j := range mibs {
-			lexer := NewLexer(mibs[j])
+		for j := 0; j < n; j++ {
+			lexer := newLexer(j)
 			for {
 				token := lexer.NextToken()
 				if token.Typ == Error {
@@ -498,6 +581,7 @@ func Benchmark_Tons(b *testing.B) {
 		buf, _ := ioutil.ReadFile(name)
 		mibs = append(mibs, string(buf))
 	}
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		for j := range mibs {
@@ -532,3 +616,17 @@ func Benchmark_BigMIB(b *testing.B) {
 		}
 	}
 }
+
+func TestTokenType_String(t *testing.T) {
+	tests := map[TokenType]string{
+		None:           "None",
+		ObjType:        "ObjType",
+		ReportOnly:     "ReportOnly",
+		ReportOnly + 1: "TokenType(" + strconv.Itoa(int(ReportOnly+1)) + ")",
+	}
+	for typ, want := range tests {
+		if got := typ.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestKeepTrivia(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "rfc1215", input: rfc1215},
+		{name: "empty", input: ""},
+		{name: "comment eof", input: "\n\t\t\t\t-- comment"},
+		{name: "inline comment", input: "a -- comment -- b -- c\r\nd\n"},
+		{name: "lone dash", input: "a - -"},
+		{name: "crlf", input: "a ::=\r\n\t{ b 1 }\r\n"},
+		{name: "literal at eof", input: "x ''"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, lexer := range []*Lexer{
+				NewLexer(tt.input, KeepTrivia()),
+				NewReaderLexer(iotest.OneByteReader(strings.NewReader(tt.input)), KeepTrivia()),
+			} {
+				var b strings.Builder
+				for {
+					tk := lexer.NextToken()
+					if tk.Typ == Error {
+						t.Fatalf("unexpected error %s", tk)
+					}
+					b.WriteString(tk.Leading + tk.Val + tk.Trailing)
+					if tk.Typ == EOF {
+						break
+					}
+				}
+				if got := b.String(); got != tt.input {
+					t.Errorf("round trip = %q, want %q", got, tt.input)
+				}
+			}
+		})
+	}
+}
+
+func TestKeepTrivia_tokens(t *testing.T) {
+	lexer := NewLexer("-- header\n\na -- note\n  ::= -- x -- b", KeepTrivia())
+	want := []Token{
+		{Typ: ValueReference, Val: "a", Leading: "-- header\n\n", Trailing: " -- note"},
+		{Typ: Equals, Val: "::=", Leading: "\n  ", Trailing: " -- x -- "},
+		{Typ: ValueReference, Val: "b"},
+		{Typ: EOF},
+	}
+	for i := range want {
+		tk := lexer.NextToken()
+		if tk.Typ != want[i].Typ || tk.Val != want[i].Val || tk.Leading != want[i].Leading || tk.Trailing != want[i].Trailing {
+			t.Errorf("token %d = %+v, want %+v", i, tk, want[i])
+		}
+	}
+}